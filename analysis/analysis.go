@@ -0,0 +1,250 @@
+package analysis
+
+import (
+	"cmp"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"math"
+	"slices"
+	"strings"
+)
+
+// How often a letter or run of letters appears in a text.
+type Count struct {
+	Gram  string `json:"gram"`
+	Count int    `json:"count"`
+	// share of all n-grams of the same length, as a percentage
+	Percent float64 `json:"percent"`
+}
+
+// A sequence found more than once in a text, with the distances between
+// consecutive occurrences. Their common factors hint at the period of a
+// polyalphabetic cipher (the Kasiski examination).
+type Repeat struct {
+	Sequence  string `json:"sequence"`
+	Positions []int  `json:"positions"`
+	Distances []int  `json:"distances"`
+}
+
+// Everything Analyze finds out about a text.
+type Report struct {
+	// letters analysed, once everything outside the alphabet is removed
+	Length             int      `json:"length"`
+	Unigrams           []Count  `json:"unigrams"`
+	Bigrams            []Count  `json:"bigrams"`
+	Trigrams           []Count  `json:"trigrams"`
+	IndexOfCoincidence float64  `json:"indexOfCoincidence"`
+	Entropy            float64  `json:"entropy"`
+	Repeats            []Repeat `json:"repeats"`
+	// against the reference frequencies, when there are any
+	ChiSquared *float64 `json:"chiSquared,omitempty"`
+}
+
+// Shortest sequence Analyze reports as repeated.
+const MinRepeatLength = 3
+
+// Counts every run of `n` consecutive letters, most frequent first and
+// alphabetically among equals.
+func NGrams(letters string, n int) []Count {
+	runes := []rune(letters)
+	if n < 1 || len(runes) < n {
+		return []Count{}
+	}
+
+	counts := map[string]int{}
+	for i := 0; i+n <= len(runes); i++ {
+		counts[string(runes[i:i+n])]++
+	}
+
+	total := len(runes) - n + 1
+	grams := make([]Count, 0, len(counts))
+	for gram, count := range counts {
+		grams = append(grams, Count{
+			Gram:    gram,
+			Count:   count,
+			Percent: 100 * float64(count) / float64(total),
+		})
+	}
+	slices.SortFunc(grams, func(a, b Count) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Gram, b.Gram)
+	})
+	return grams
+}
+
+// The chance that two letters picked at random from the text are the
+// same. English is around 0.067 and random letters 1/26, about 0.038.
+// https://en.wikipedia.org/wiki/Index_of_coincidence
+func IndexOfCoincidence(letters string) float64 {
+	counts := map[rune]int{}
+	total := 0
+	for _, c := range letters {
+		counts[c]++
+		total++
+	}
+	if total < 2 {
+		return 0
+	}
+
+	sum := 0
+	for _, n := range counts {
+		sum += n * (n - 1)
+	}
+	return float64(sum) / float64(total*(total-1))
+}
+
+// Pearson's chi-squared statistic comparing the letter counts with
+// `reference` frequencies given as percentages, such as
+// lookup.EnglishFrequencies. The lower it is, the closer the text is to
+// the reference language. Letters missing from the reference are left
+// out, and a text with none of its letters scores 0.
+func ChiSquared(letters string, reference map[rune]float64) float64 {
+	counts := map[rune]int{}
+	total := 0
+	for _, c := range letters {
+		if _, ok := reference[c]; ok {
+			counts[c]++
+			total++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	chi := 0.0
+	for c, freq := range reference {
+		expected := float64(total) * freq / 100
+		if expected == 0 {
+			continue
+		}
+		diff := float64(counts[c]) - expected
+		chi += diff * diff / expected
+	}
+	return chi
+}
+
+// Shannon entropy of the letters in bits per letter. English is around
+// 4.2 and evenly spread letters log2(26), about 4.7.
+func Entropy(letters string) float64 {
+	counts := map[rune]int{}
+	total := 0
+	for _, c := range letters {
+		counts[c]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, n := range counts {
+		p := float64(n) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// Finds sequences of at least `minLength` letters that appear more than
+// once. A sequence is left out when it only ever appears inside a longer
+// repeated one. Longest sequences come first.
+func RepeatedSequences(letters string, minLength int) []Repeat {
+	runes := []rune(letters)
+	minLength = max(minLength, 1)
+	repeats := []Repeat{}
+
+	for length := minLength; length <= len(runes)/2; length++ {
+		positions := map[string][]int{}
+		for i := 0; i+length <= len(runes); i++ {
+			seq := string(runes[i : i+length])
+			positions[seq] = append(positions[seq], i)
+		}
+
+		found := false
+		for seq, pos := range positions {
+			if len(pos) < 2 {
+				continue
+			}
+			found = true
+			distances := make([]int, len(pos)-1)
+			for i := 1; i < len(pos); i++ {
+				distances[i-1] = pos[i] - pos[i-1]
+			}
+			repeats = append(repeats, Repeat{
+				Sequence:  seq,
+				Positions: pos,
+				Distances: distances,
+			})
+		}
+		// nothing repeats at this length, so nothing longer can either
+		if !found {
+			break
+		}
+	}
+
+	slices.SortFunc(repeats, func(a, b Repeat) int {
+		if la, lb := len([]rune(a.Sequence)), len([]rune(b.Sequence)); la != lb {
+			return lb - la
+		}
+		if len(a.Positions) != len(b.Positions) {
+			return len(b.Positions) - len(a.Positions)
+		}
+		return cmp.Compare(a.Positions[0], b.Positions[0])
+	})
+
+	maximal := []Repeat{}
+	for _, r := range repeats {
+		if !containedIn(r, maximal) {
+			maximal = append(maximal, r)
+		}
+	}
+	return maximal
+}
+
+// Whether every occurrence of `r` lies within an occurrence of one of
+// the longer repeats.
+func containedIn(r Repeat, longer []Repeat) bool {
+	length := len([]rune(r.Sequence))
+	for _, l := range longer {
+		if len(l.Positions) != len(r.Positions) {
+			continue
+		}
+		offset := r.Positions[0] - l.Positions[0]
+		if offset < 0 || offset+length > len([]rune(l.Sequence)) {
+			continue
+		}
+		inside := true
+		for i, pos := range r.Positions {
+			if pos-l.Positions[i] != offset {
+				inside = false
+				break
+			}
+		}
+		if inside {
+			return true
+		}
+	}
+	return false
+}
+
+// Analyses the letters of `s` that belong to `alphabet`, folded to its
+// case. Chi-squared is only worked out when `reference` frequencies are
+// given.
+func Analyze(s string, alphabet *lookup.Alphabet, reference map[rune]float64) *Report {
+	if alphabet == nil {
+		alphabet = lookup.Latin
+	}
+	letters := alphabet.Filter(s)
+
+	report := &Report{
+		Length:             len([]rune(letters)),
+		Unigrams:           NGrams(letters, 1),
+		Bigrams:            NGrams(letters, 2),
+		Trigrams:           NGrams(letters, 3),
+		IndexOfCoincidence: IndexOfCoincidence(letters),
+		Entropy:            Entropy(letters),
+		Repeats:            RepeatedSequences(letters, MinRepeatLength),
+	}
+	if len(reference) > 0 {
+		chi := ChiSquared(letters, reference)
+		report.ChiSquared = &chi
+	}
+	return report
+}
@@ -0,0 +1,88 @@
+package analysis
+
+import (
+	"github.com/stretchr/testify/suite"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"testing"
+)
+
+type AnalysisTest struct {
+	suite.Suite
+	english string
+}
+
+func (suite *AnalysisTest) SetupTest() {
+	suite.english = "It was the best of times, it was the worst of times, it was the age " +
+		"of wisdom, it was the age of foolishness, it was the epoch of belief, it " +
+		"was the epoch of incredulity, it was the season of Light, it was the " +
+		"season of Darkness"
+}
+
+func (suite *AnalysisTest) TestNGrams() {
+	suite.Equal([]Count{
+		{Gram: "L", Count: 2, Percent: 40},
+		{Gram: "E", Count: 1, Percent: 20},
+		{Gram: "H", Count: 1, Percent: 20},
+		{Gram: "O", Count: 1, Percent: 20},
+	}, NGrams("HELLO", 1))
+
+	bigrams := NGrams("HELLO", 2)
+	suite.Len(bigrams, 4)
+	suite.Equal(Count{Gram: "EL", Count: 1, Percent: 25}, bigrams[0])
+
+	suite.Empty(NGrams("HI", 3))
+	suite.Empty(NGrams("HI", 0))
+}
+
+func (suite *AnalysisTest) TestIndexOfCoincidence() {
+	suite.InDelta(1.0, IndexOfCoincidence("AAAA"), 1e-9)
+	suite.InDelta(0.0, IndexOfCoincidence("ABCD"), 1e-9)
+	// two pairs out of six possible pairs
+	suite.InDelta(2.0/6, IndexOfCoincidence("AABB"), 1e-9)
+	suite.Zero(IndexOfCoincidence("A"))
+
+	ioc := IndexOfCoincidence(lookup.Latin.Filter(suite.english))
+	suite.Greater(ioc, 0.055)
+}
+
+func (suite *AnalysisTest) TestChiSquared() {
+	english := lookup.Latin.Filter(suite.english)
+	shifted := lookup.Latin.Filter("Lw zdv wkhehvw ri wlphv, lw zdv wkh zruvw ri wlphv")
+
+	suite.Less(
+		ChiSquared(english, lookup.EnglishFrequencies),
+		ChiSquared(shifted, lookup.EnglishFrequencies),
+	)
+	suite.InDelta(0.0, ChiSquared("ΑΒΓ", lookup.EnglishFrequencies), 1e-9)
+}
+
+func (suite *AnalysisTest) TestEntropy() {
+	suite.InDelta(0.0, Entropy("AAAA"), 1e-9)
+	suite.InDelta(2.0, Entropy("ABCD"), 1e-9)
+	suite.InDelta(0.0, Entropy(""), 1e-9)
+}
+
+func (suite *AnalysisTest) TestRepeatedSequences() {
+	repeats := RepeatedSequences("LXFOPVEFRNHRLXFOPVABCTHEXTHEYTHE", 3)
+	suite.Equal([]Repeat{
+		{Sequence: "LXFOPV", Positions: []int{0, 12}, Distances: []int{12}},
+		{Sequence: "THE", Positions: []int{21, 25, 29}, Distances: []int{4, 4}},
+	}, repeats)
+
+	suite.Empty(RepeatedSequences("ABCDEFG", 3))
+}
+
+func (suite *AnalysisTest) TestAnalyze() {
+	report := Analyze("Attack at dawn, attack at dusk", nil, lookup.EnglishFrequencies)
+	suite.Equal(24, report.Length)
+	suite.Equal("A", report.Unigrams[0].Gram)
+	suite.Equal(7, report.Unigrams[0].Count)
+	suite.NotNil(report.ChiSquared)
+	suite.Equal("ATTACKATD", report.Repeats[0].Sequence)
+
+	suite.Nil(Analyze("αβγ", lookup.Greek, nil).ChiSquared)
+}
+
+func TestAnalysis(t *testing.T) {
+	suite.Run(t, new(AnalysisTest))
+}
@@ -0,0 +1,210 @@
+package analysis
+
+// The typical value of a statistic for a cipher type, and how far it
+// usually strays either side.
+type Expected struct {
+	Mean   float64 `json:"mean"`
+	Spread float64 `json:"spread"`
+}
+
+// What ciphertexts of one type look like, for RankTypes. A type only
+// competes when the ciphertext is written in its alphabet and passes its
+// checks, and is then scored on the features it expects, keyed as in
+// Statistics.Features.
+type CipherType struct {
+	Name string `json:"name"`
+	// cipher commands that write this type
+	Commands []string `json:"commands"`
+	Alphabet string   `json:"alphabet"`
+	// most distinct symbols the ciphertext can have, or 0 for no limit
+	MaxSymbols int `json:"maxSymbols"`
+	// written in pairs: an even number of symbols and no pair that is
+	// the same symbol twice
+	Digraphic bool `json:"digraphic"`
+	// never writes J, which a 5 x 5 square merges with I
+	NoJ      bool                `json:"noJ"`
+	Features map[string]Expected `json:"features"`
+}
+
+// The types RankTypes tries, starting with the ciphers this project
+// implements. Each profile was measured with cmd/profiles by encoding
+// 200 stretches of 300 letters of English prose, run together without
+// spaces or punctuation as ACA ciphertexts are, under random keys. The
+// long repeats statistic in particular grows with the length of the
+// text, so much shorter or longer ciphertexts fit less well. Ciphers
+// indistinguishable by these statistics share a profile.
+var CipherTypes = []CipherType{
+	{
+		Name:     "Transposition",
+		Commands: []string{"columnar", "scytale", "nihilist-transposition", "turning-grille", "cadenus", "swagman"},
+		Alphabet: AlphabetLetters,
+		Features: map[string]Expected{
+			"ioc":    {0.0682, 0.0051},
+			"mic":    {0.0747, 0.0065},
+			"lr":     {6.9, 3.5},
+			"dic":    {0.006, 0.003},
+			"edi":    {0.006, 0.003},
+			"normor": {66, 14},
+		},
+	},
+	{
+		Name:     "Simple substitution",
+		Commands: []string{"caesar", "substitution"},
+		Alphabet: AlphabetLetters,
+		Features: map[string]Expected{
+			"ioc":    {0.0687, 0.0055},
+			"mic":    {0.0766, 0.0074},
+			"lr":     {25.6, 8.1},
+			"dic":    {0.0091, 0.002},
+			"edi":    {0.0092, 0.0025},
+			"normor": {219, 26},
+		},
+	},
+	{
+		Name:     "Periodic polyalphabetic",
+		Commands: []string{"vigenere", "porta", "gronsfeld", "quagmire1", "quagmire2", "quagmire3", "quagmire4"},
+		Alphabet: AlphabetLetters,
+		Features: map[string]Expected{
+			"ioc":    {0.044, 0.0035},
+			"mic":    {0.0705, 0.0065},
+			"lr":     {7.0, 4.3},
+			"dic":    {0.0028, 0.00078},
+			"edi":    {0.0035, 0.0013},
+			"normor": {222, 33},
+		},
+	},
+	{
+		Name:     "Nicodemus",
+		Commands: []string{"nicodemus"},
+		Alphabet: AlphabetLetters,
+		Features: map[string]Expected{
+			"ioc":    {0.0449, 0.0038},
+			"mic":    {0.054, 0.0095},
+			"lr":     {2.1, 1.8},
+			"dic":    {0.00227, 0.00058},
+			"edi":    {0.00232, 0.00069},
+			"normor": {222, 32},
+		},
+	},
+	{
+		Name:     "Aperiodic polyalphabetic",
+		Commands: []string{"trithemius", "progressive-key", "alberti", "gromark", "periodic-gromark"},
+		Alphabet: AlphabetLetters,
+		Features: map[string]Expected{
+			"ioc":    {0.0387, 0.0012},
+			"mic":    {0.0463, 0.0065},
+			"lr":     {1.4, 1.1},
+			"dic":    {0.0016, 0.00025},
+			"edi":    {0.00166, 0.0005},
+			"normor": {221, 28},
+		},
+	},
+	{
+		Name:       "Ragbaby",
+		Commands:   []string{"ragbaby"},
+		Alphabet:   AlphabetLetters,
+		MaxSymbols: 24,
+		Features: map[string]Expected{
+			"ioc":    {0.0417, 0.0009},
+			"mic":    {0.0551, 0.0057},
+			"lr":     {2.7, 1.9},
+			"dic":    {0.00193, 0.00027},
+			"edi":    {0.00223, 0.00054},
+			"normor": {197, 24},
+		},
+	},
+	{
+		Name:       "Playfair",
+		Commands:   []string{"playfair"},
+		Alphabet:   AlphabetLetters,
+		MaxSymbols: 25,
+		Digraphic:  true,
+		NoJ:        true,
+		Features: map[string]Expected{
+			"ioc":    {0.0523, 0.0043},
+			"mic":    {0.0598, 0.0056},
+			"lr":     {14.1, 6.2},
+			"dic":    {0.0049, 0.001},
+			"edi":    {0.0095, 0.0023},
+			"normor": {211, 30},
+		},
+	},
+	{
+		Name:     "Hill",
+		Commands: []string{"hill"},
+		Alphabet: AlphabetLetters,
+		Features: map[string]Expected{
+			"ioc":    {0.041, 0.0028},
+			"mic":    {0.0488, 0.0065},
+			"lr":     {9.2, 5.5},
+			"dic":    {0.0031, 0.0011},
+			"edi":    {0.0057, 0.0038},
+			"normor": {219, 29},
+		},
+	},
+	{
+		Name:     "Fractionated Morse",
+		Commands: []string{"fractionated-morse"},
+		Alphabet: AlphabetLetters,
+		Features: map[string]Expected{
+			"ioc":    {0.0587, 0.0031},
+			"mic":    {0.064, 0.0041},
+			"lr":     {19.0, 4.6},
+			"dic":    {0.00627, 0.00068},
+			"edi":    {0.00633, 0.00095},
+			"normor": {199, 30},
+		},
+	},
+	{
+		Name:       "Baconian",
+		Commands:   []string{"bacon"},
+		Alphabet:   AlphabetLetters,
+		MaxSymbols: 2,
+		Features: map[string]Expected{
+			"ioc": {0.546, 0.0075},
+			"mic": {0.551, 0.0077},
+			"lr":  {99.5, 0.5},
+			"dic": {0.296, 0.0077},
+			"edi": {0.296, 0.0077},
+		},
+	},
+	{
+		Name:       "Nihilist substitution",
+		Commands:   []string{"nihilist"},
+		Alphabet:   AlphabetDigits,
+		MaxSymbols: 10,
+		Features: map[string]Expected{
+			"ioc": {0.145, 0.015},
+			"mic": {0.179, 0.041},
+			"lr":  {57, 5.3},
+			"dic": {0.0233, 0.004},
+			"edi": {0.0262, 0.0064},
+		},
+	},
+	{
+		Name:       "Morbit",
+		Commands:   []string{"morbit"},
+		Alphabet:   AlphabetDigits,
+		MaxSymbols: 9,
+		Features: map[string]Expected{
+			"ioc": {0.136, 0.0035},
+			"mic": {0.142, 0.0044},
+			"lr":  {64, 2.0},
+			"dic": {0.026, 0.0014},
+			"edi": {0.0261, 0.0019},
+		},
+	},
+	{
+		Name:       "Homophonic substitution",
+		Commands:   []string{"homophonic"},
+		Alphabet:   AlphabetDigits,
+		MaxSymbols: 10,
+		Features: map[string]Expected{
+			"ioc": {0.101, 0.00098},
+			"mic": {0.105, 0.0021},
+			"lr":  {27, 1.8},
+			"dic": {0.0104, 0.00033},
+			"edi": {0.0113, 0.00072},
+		},
+	},
+}
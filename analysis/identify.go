@@ -0,0 +1,302 @@
+package analysis
+
+import (
+	"cmp"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// What a ciphertext is written in, as far as telling cipher types apart
+// goes.
+const (
+	AlphabetLetters = "letters"
+	AlphabetDigits  = "digits"
+	AlphabetMixed   = "letters and digits"
+	AlphabetOther   = "other"
+)
+
+// Longest period tried for the maximum periodic index of coincidence.
+const MaxIdentifyPeriod = 15
+
+// The statistics the ACA uses to tell cipher types apart, worked out on
+// the letters and digits of a ciphertext.
+// https://www.cryptogram.org/resource-area/cipher-types/
+type Statistics struct {
+	// letters and digits counted
+	Length int `json:"length"`
+	// distinct letters and digits
+	Symbols  int    `json:"symbols"`
+	Alphabet string `json:"alphabet"`
+	// IC
+	IndexOfCoincidence float64 `json:"indexOfCoincidence"`
+	// MIC, the highest mean index of coincidence of the columns when the
+	// text is written out in rows of each period up to MaxIdentifyPeriod
+	MaxPeriodicIoC float64 `json:"maxPeriodicIoC"`
+	MaxIoCPeriod   int     `json:"maxIoCPeriod"`
+	LongestRepeat  int     `json:"longestRepeat"`
+	// LR, the share of runs of three symbols that repeat an earlier one,
+	// as a percentage
+	LongRepeats float64 `json:"longRepeats"`
+	// DIC, the index of coincidence of every overlapping pair of symbols
+	DigraphicIoC float64 `json:"digraphicIoC"`
+	// EDI, the same for the pairs starting at even positions only
+	EvenDigraphicIoC float64 `json:"evenDigraphicIoC"`
+	// how far the letters, ranked by frequency, are from English's
+	// normal order: the sum of the differences in rank
+	Normor     int  `json:"normor"`
+	EvenLength bool `json:"evenLength"`
+	HasJ       bool `json:"hasJ"`
+	// a pair starting at an even position is the same symbol twice, which
+	// digraphic ciphers like Playfair never write
+	DoubledDigraph bool `json:"doubledDigraph"`
+}
+
+// Index of coincidence of the pairs of symbols starting every `step`
+// positions.
+func digraphicIoC(symbols []rune, step int) float64 {
+	counts := map[[2]rune]int{}
+	total := 0
+	for i := 0; i+1 < len(symbols); i += step {
+		counts[[2]rune{symbols[i], symbols[i+1]}]++
+		total++
+	}
+	if total < 2 {
+		return 0
+	}
+
+	sum := 0
+	for _, n := range counts {
+		sum += n * (n - 1)
+	}
+	return float64(sum) / float64(total*(total-1))
+}
+
+// Highest mean index of coincidence of the columns over periods 1 to
+// MaxIdentifyPeriod, and the period it was found at.
+func maxPeriodicIoC(symbols []rune) (float64, int) {
+	best, bestPeriod := 0.0, 0
+	for period := 1; period <= MaxIdentifyPeriod && period*2 <= len(symbols); period++ {
+		sum := 0.0
+		for start := 0; start < period; start++ {
+			var column strings.Builder
+			for i := start; i < len(symbols); i += period {
+				column.WriteRune(symbols[i])
+			}
+			sum += IndexOfCoincidence(column.String())
+		}
+		if mean := sum / float64(period); mean > best {
+			best, bestPeriod = mean, period
+		}
+	}
+	return best, bestPeriod
+}
+
+// Sum over A to Z of the difference between each letter's rank by count
+// in the text and its rank in English. Ties are ranked alphabetically.
+func normor(symbols []rune) int {
+	counts := map[rune]int{}
+	for _, c := range symbols {
+		counts[c]++
+	}
+
+	english := []rune(lookup.Latin.String())
+	text := slices.Clone(english)
+	slices.SortStableFunc(english, func(a, b rune) int {
+		return cmp.Compare(lookup.EnglishFrequencies[b], lookup.EnglishFrequencies[a])
+	})
+	slices.SortStableFunc(text, func(a, b rune) int {
+		return counts[b] - counts[a]
+	})
+
+	rank := map[rune]int{}
+	for i, c := range english {
+		rank[c] = i
+	}
+	sum := 0
+	for i, c := range text {
+		sum += max(i-rank[c], rank[c]-i)
+	}
+	return sum
+}
+
+// Works out the identification statistics for the letters and digits of
+// `s`. Letters are folded to uppercase, and everything else is ignored.
+func IdentifyStatistics(s string) *Statistics {
+	symbols := []rune{}
+	letters, digits := false, false
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits = true
+		case unicode.IsLetter(c):
+			letters = true
+			c = unicode.ToUpper(c)
+		default:
+			continue
+		}
+		symbols = append(symbols, c)
+	}
+
+	stats := &Statistics{
+		Length:             len(symbols),
+		IndexOfCoincidence: IndexOfCoincidence(string(symbols)),
+		DigraphicIoC:       digraphicIoC(symbols, 1),
+		EvenDigraphicIoC:   digraphicIoC(symbols, 2),
+		Normor:             normor(symbols),
+		EvenLength:         len(symbols)%2 == 0,
+		HasJ:               slices.Contains(symbols, 'J'),
+	}
+	stats.MaxPeriodicIoC, stats.MaxIoCPeriod = maxPeriodicIoC(symbols)
+
+	switch {
+	case letters && digits:
+		stats.Alphabet = AlphabetMixed
+	case digits:
+		stats.Alphabet = AlphabetDigits
+	case letters:
+		stats.Alphabet = AlphabetLetters
+	default:
+		stats.Alphabet = AlphabetOther
+	}
+	for _, c := range symbols {
+		if c > unicode.MaxASCII {
+			stats.Alphabet = AlphabetOther
+			break
+		}
+	}
+
+	distinct := map[rune]bool{}
+	for _, c := range symbols {
+		distinct[c] = true
+	}
+	stats.Symbols = len(distinct)
+
+	for i := 0; i+1 < len(symbols); i += 2 {
+		if symbols[i] == symbols[i+1] {
+			stats.DoubledDigraph = true
+			break
+		}
+	}
+
+	trigrams := map[string]bool{}
+	repeated := 0
+	for i := 0; i+3 <= len(symbols); i++ {
+		trigram := string(symbols[i : i+3])
+		if trigrams[trigram] {
+			repeated++
+		}
+		trigrams[trigram] = true
+	}
+	if len(symbols) >= 3 {
+		stats.LongRepeats = 100 * float64(repeated) / float64(len(symbols)-2)
+	}
+	if repeats := RepeatedSequences(string(symbols), MinRepeatLength); len(repeats) > 0 {
+		stats.LongestRepeat = len([]rune(repeats[0].Sequence))
+	}
+
+	return stats
+}
+
+// The statistics a CipherType is ranked on, by name.
+func (s *Statistics) Features() map[string]float64 {
+	return map[string]float64{
+		"ioc":    s.IndexOfCoincidence,
+		"mic":    s.MaxPeriodicIoC,
+		"lr":     s.LongRepeats,
+		"dic":    s.DigraphicIoC,
+		"edi":    s.EvenDigraphicIoC,
+		"normor": float64(s.Normor),
+	}
+}
+
+// How likely a cipher type is to have produced a ciphertext.
+type Guess struct {
+	Type     string   `json:"type"`
+	Commands []string `json:"commands"`
+	// log likelihood of the statistics under the type's profile
+	Score float64 `json:"score"`
+	// share of the likelihood of all the types ranked, as a percentage
+	Percent float64 `json:"percent"`
+}
+
+// The statistics of a ciphertext and the cipher types that fit them.
+type Identification struct {
+	Statistics *Statistics `json:"statistics"`
+	Guesses    []Guess     `json:"guesses"`
+}
+
+// Whether a ciphertext with these statistics could have been written
+// by the cipher type at all.
+func (t *CipherType) fits(stats *Statistics) bool {
+	if t.Alphabet != stats.Alphabet {
+		return false
+	}
+	if t.MaxSymbols > 0 && stats.Symbols > t.MaxSymbols {
+		return false
+	}
+	if t.Digraphic && (!stats.EvenLength || stats.DoubledDigraph) {
+		return false
+	}
+	if t.NoJ && stats.HasJ {
+		return false
+	}
+	return true
+}
+
+// Ranks the types that fit the statistics, most likely first. Each
+// feature a type expects adds the log of a normal density around its
+// typical value, so one far from it counts heavily against the type.
+func RankTypes(stats *Statistics, types []CipherType) []Guess {
+	features := stats.Features()
+	guesses := []Guess{}
+	for _, t := range types {
+		if !t.fits(stats) {
+			continue
+		}
+
+		score := 0.0
+		for name, expected := range t.Features {
+			value, ok := features[name]
+			if !ok {
+				continue
+			}
+			spread := max(expected.Spread, 1e-6)
+			z := (value - expected.Mean) / spread
+			score += -z*z/2 - math.Log(spread)
+		}
+		// pairs that never double a symbol are evidence for a digraphic
+		// cipher, since any other writes a doubled pair about as often as
+		// two symbols coincide
+		if !t.Digraphic && stats.EvenLength && !stats.DoubledDigraph {
+			score += float64(stats.Length/2) * math.Log(1-stats.IndexOfCoincidence)
+		}
+		guesses = append(guesses, Guess{Type: t.Name, Commands: t.Commands, Score: score})
+	}
+	if len(guesses) == 0 {
+		return guesses
+	}
+
+	slices.SortStableFunc(guesses, func(a, b Guess) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	total := 0.0
+	for _, g := range guesses {
+		total += math.Exp(g.Score - guesses[0].Score)
+	}
+	for i := range guesses {
+		guesses[i].Percent = 100 * math.Exp(guesses[i].Score-guesses[0].Score) / total
+	}
+	return guesses
+}
+
+// Works out the statistics of `s` and ranks CipherTypes against them.
+func Identify(s string) *Identification {
+	stats := IdentifyStatistics(s)
+	return &Identification{
+		Statistics: stats,
+		Guesses:    RankTypes(stats, CipherTypes),
+	}
+}
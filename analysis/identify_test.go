@@ -0,0 +1,97 @@
+package analysis
+
+import (
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type IdentifyTest struct {
+	suite.Suite
+	plain string
+}
+
+func (suite *IdentifyTest) SetupTest() {
+	// the opening of the Gettysburg Address
+	suite.plain = "FOURSCOREANDSEVENYEARSAGOOURFATHERSBROUGHTFORTHONTHISCONTINENTANEWNATION" +
+		"CONCEIVEDINLIBERTYANDDEDICATEDTOTHEPROPOSITIONTHATALLMENARECREATEDEQUAL" +
+		"NOWWEAREENGAGEDINAGREATCIVILWARTESTINGWHETHERTHATNATIONORANYNATIONSO" +
+		"CONCEIVEDANDSODEDICATEDCANLONGENDURE"
+}
+
+func (suite *IdentifyTest) TestStatistics() {
+	stats := IdentifyStatistics("ab ab-AB!")
+	suite.Equal(6, stats.Length)
+	suite.Equal(2, stats.Symbols)
+	suite.Equal(AlphabetLetters, stats.Alphabet)
+	// six pairs of the fifteen possible match
+	suite.InDelta(6.0/15, stats.IndexOfCoincidence, 1e-9)
+	suite.InDelta(1.0, stats.MaxPeriodicIoC, 1e-9)
+	suite.Equal(2, stats.MaxIoCPeriod)
+	suite.Equal(3, stats.LongestRepeat)
+	suite.InDelta(50.0, stats.LongRepeats, 1e-9)
+	suite.InDelta(1.0, stats.EvenDigraphicIoC, 1e-9)
+	suite.True(stats.EvenLength)
+	suite.False(stats.HasJ)
+	suite.False(stats.DoubledDigraph)
+
+	suite.Equal(AlphabetDigits, IdentifyStatistics("12 34").Alphabet)
+	suite.Equal(AlphabetMixed, IdentifyStatistics("A1").Alphabet)
+	suite.Equal(AlphabetOther, IdentifyStatistics("αβ").Alphabet)
+	suite.True(IdentifyStatistics("JJ").DoubledDigraph)
+}
+
+func (suite *IdentifyTest) TestNormor() {
+	shifted := strings.Map(func(c rune) rune {
+		return 'A' + (c-'A'+3)%26
+	}, suite.plain)
+	suite.Less(IdentifyStatistics(suite.plain).Normor, 100)
+	suite.Greater(IdentifyStatistics(shifted).Normor, 150)
+}
+
+func (suite *IdentifyTest) TestRankTypes() {
+	types := []CipherType{
+		{Name: "pairs", Alphabet: AlphabetLetters, Digraphic: true},
+		{Name: "few", Alphabet: AlphabetLetters, MaxSymbols: 2},
+		{
+			Name:     "english",
+			Alphabet: AlphabetLetters,
+			Features: map[string]Expected{"ioc": {0.067, 0.005}},
+		},
+		{Name: "numbers", Alphabet: AlphabetDigits},
+	}
+
+	guesses := RankTypes(IdentifyStatistics(suite.plain), types)
+	names := []string{}
+	total := 0.0
+	for _, g := range guesses {
+		names = append(names, g.Type)
+		total += g.Percent
+	}
+	// the plaintext has doubled pairs and too many letters for the others
+	suite.Equal([]string{"english"}, names)
+	suite.InDelta(100.0, total, 1e-9)
+
+	suite.Empty(RankTypes(IdentifyStatistics("123"), types[:3]))
+
+	noJ := []CipherType{{Name: "no j", Alphabet: AlphabetLetters, NoJ: true}}
+	suite.Len(RankTypes(IdentifyStatistics(suite.plain), noJ), 1)
+	suite.Empty(RankTypes(IdentifyStatistics(suite.plain+"JUSTLY"), noJ))
+}
+
+func (suite *IdentifyTest) TestIdentify() {
+	// the plaintext written out in four rows of a scytale
+	rows := make([]string, 4)
+	for i, c := range suite.plain {
+		rows[i%4] += string(c)
+	}
+	id := Identify(strings.Join(rows, ""))
+	suite.Equal("Transposition", id.Guesses[0].Type)
+	suite.Contains(id.Guesses[0].Commands, "scytale")
+
+	suite.Equal("Baconian", Identify(strings.Repeat("AABAB ABBAA BAAAB ", 20)).Guesses[0].Type)
+}
+
+func TestIdentify(t *testing.T) {
+	suite.Run(t, new(IdentifyTest))
+}
@@ -0,0 +1,116 @@
+package ciphers
+
+import (
+	"errors"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"strings"
+	"unicode"
+)
+
+// https://en.wikipedia.org/wiki/Alberti_cipher
+type Alberti struct {
+	// fixed outer disk holding the plaintext letters, A to Z
+	outer *lookup.Alphabet
+	// movable inner disk holding the mixed lowercase cipher alphabet
+	inner *lookup.Alphabet
+	// letter on the inner disk used to set the disk against the outer one
+	index rune
+	// letters enciphered before each turn of the inner disk, 0 never turns
+	period int
+	// places the inner disk turns each time
+	step int
+	// announce each turn in the ciphertext with the outer (uppercase)
+	// letter now above the index letter, instead of agreeing the turns
+	// in advance
+	inText bool
+	Encoder
+	Decoder
+}
+
+// Offset between the disks when the index letter sits under `outerLetter`.
+func (a *Alberti) setting(outerLetter rune) int {
+	return a.inner.Index(a.index) - a.outer.Index(outerLetter)
+}
+
+func (a *Alberti) validate() error {
+	if a.outer.Pair() == nil {
+		return errors.New("alphabet must have upper and lower case")
+	}
+	if !a.inner.Contains(a.index) {
+		return errors.New("index letter must be on the inner disk")
+	}
+	if a.period < 0 {
+		return errors.New("expected non-negative integer period")
+	}
+	return nil
+}
+
+// Encode drops anything that isn't a letter and returns lowercase
+// ciphertext, with uppercase setting letters when the turns are
+// announced in the text.
+func (a *Alberti) Encode(s string) (string, error) {
+	if err := a.validate(); err != nil {
+		return "", err
+	}
+
+	var encoded strings.Builder
+	outerLetter := a.outer.At(0)
+	if a.inText {
+		encoded.WriteRune(outerLetter)
+	}
+
+	for i, c := range []rune(a.outer.Filter(s)) {
+		if a.period > 0 && i > 0 && i%a.period == 0 {
+			outerLetter = a.outer.At(a.outer.Index(outerLetter) + a.step)
+			if a.inText {
+				encoded.WriteRune(outerLetter)
+			}
+		}
+		encoded.WriteRune(a.inner.At(a.outer.Index(c) + a.setting(outerLetter)))
+	}
+
+	return encoded.String(), nil
+}
+
+func (a *Alberti) Decode(s string) (string, error) {
+	if err := a.validate(); err != nil {
+		return "", err
+	}
+
+	var decoded strings.Builder
+	outerLetter := a.outer.At(0)
+	letterCount := 0
+
+	for _, c := range s {
+		switch {
+		case a.inText && a.outer.Contains(c):
+			// setting letter, turn the disk to it
+			outerLetter = c
+		case a.inner.Contains(c):
+			if !a.inText && a.period > 0 && letterCount > 0 && letterCount%a.period == 0 {
+				outerLetter = a.outer.At(a.outer.Index(outerLetter) + a.step)
+			}
+			decoded.WriteRune(a.outer.At(a.inner.Index(c) - a.setting(outerLetter)))
+			letterCount++
+		case unicode.IsLetter(c):
+			return "", errors.New("decoding failed")
+		}
+	}
+
+	return decoded.String(), nil
+}
+
+// `inner` may be a complete mixed alphabet or a keyword to mix one from.
+// With another alphabet, the outer disk holds its upper case and the
+// inner disk its lower case.
+func NewAlberti(inner string, index rune, period int, step int, inText bool, opts ...Option) *Alberti {
+	alphabet := newOptions(opts).alphabet
+	return &Alberti{
+		outer:  alphabet.Upper(),
+		inner:  lookup.NewKeyedAlphabet(inner, alphabet).Lower(),
+		index:  unicode.ToLower(index),
+		period: period,
+		step:   step,
+		inText: inText,
+	}
+}
@@ -0,0 +1,92 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type albertiCase struct {
+	alberti *Alberti
+	plain   string
+	encoded string
+	decoded string
+}
+
+type AlbertiTest struct {
+	suite.Suite
+	cases []*albertiCase
+}
+
+func (suite *AlbertiTest) SetupTest() {
+	straight := "abcdefghijklmnopqrstuvwxyz"
+
+	suite.cases = []*albertiCase{
+		// a fixed disk setting is a simple substitution
+		{
+			alberti: NewAlberti(straight, 'd', 0, 0, false),
+			plain:   "Hello!",
+			encoded: "khoor",
+			decoded: "HELLO",
+		},
+		{
+			alberti: NewAlberti("kryptos", 'k', 0, 0, false),
+			plain:   "AB",
+			encoded: "kr",
+			decoded: "AB",
+		},
+		// turns agreed in advance
+		{
+			alberti: NewAlberti(straight, 'a', 2, 1, false),
+			plain:   "ABCD",
+			encoded: "abbc",
+			decoded: "ABCD",
+		},
+		// turns announced in the ciphertext
+		{
+			alberti: NewAlberti(straight, 'a', 2, 1, true),
+			plain:   "ABCD",
+			encoded: "AabBbc",
+			decoded: "ABCD",
+		},
+	}
+}
+
+func (suite *AlbertiTest) TestEncoding() {
+	for _, cs := range suite.cases {
+		enc, err := cs.alberti.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *AlbertiTest) TestDecoding() {
+	for _, cs := range suite.cases {
+		dec, err := cs.alberti.Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.decoded, dec)
+	}
+}
+
+func (suite *AlbertiTest) TestSettingLetters() {
+	// the receiver follows whatever setting letters appear in the text
+	ab := NewAlberti("abcdefghijklmnopqrstuvwxyz", 'a', 0, 0, true)
+	dec, err := ab.Decode("Dab Zab")
+	suite.Nil(err)
+	suite.Equal("DEZA", dec)
+}
+
+func (suite *AlbertiTest) TestErrors() {
+	ab := NewAlberti("kryptos", '1', 0, 0, false)
+	_, err := ab.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("index letter must be on the inner disk", err.Error())
+
+	ab = NewAlberti("kryptos", 'k', -1, 0, false)
+	_, err = ab.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("expected non-negative integer period", err.Error())
+}
+
+func TestAlberti(t *testing.T) {
+	suite.Run(t, new(AlbertiTest))
+}
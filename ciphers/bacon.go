@@ -0,0 +1,308 @@
+package ciphers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// https://en.wikipedia.org/wiki/Bacon%27s_cipher
+//
+// Each letter becomes a group of five `A`s and `B`s. The groups can be
+// written out with any pair of symbols, or hidden in an innocent carrier
+// text where each carrier letter stands for an `A` or a `B` depending on
+// how it is styled.
+type Bacon struct {
+	// use a distinct group for every letter, rather than Bacon's original
+	// 24 letter alphabet where I/J and U/V share a group
+	distinct bool
+	// symbols written for `A` and `B`
+	symbols [2]rune
+	Encoder
+	Decoder
+}
+
+// How a carrier text marks its `B` letters.
+type CarrierStyle int
+
+const (
+	// `A` letters are lower case, `B` letters upper case
+	CaseStyle CarrierStyle = iota
+	// `B` letters are wrapped in `*`, as if set in a bold font
+	MarkerStyle
+	// `B` letters are drawn from a second symbol set, Unicode's
+	// mathematical sans-serif letters, as Bacon set them in a second
+	// typeface. Only the letters A to Z of the carrier carry the message.
+	SymbolSetStyle
+)
+
+const baconMarker = '*'
+
+// First letters of the second symbol set, 𝖠 and 𝖺.
+const (
+	baconSymbolUpper = '\U0001D5A0'
+	baconSymbolLower = '\U0001D5BA'
+)
+
+// Whether the style can hide a bit in the carrier letter `c`. Case can
+// only hide one in letters that have case.
+func carriesBit(c rune, style CarrierStyle) bool {
+	switch style {
+	case CaseStyle:
+		return unicode.IsUpper(c) || unicode.IsLower(c)
+	case SymbolSetStyle:
+		return c < unicode.MaxASCII && unicode.IsLetter(c) || fromSymbolSet(c) != 0
+	}
+	return unicode.IsLetter(c)
+}
+
+// Hidden messages end with a group of five `B`s, which no letter uses,
+// so that the unmarked carrier letters after them aren't read as `A`s.
+const baconEndGroup = 0b11111
+
+// The ASCII letter written as `c` in the second symbol set, or 0.
+func fromSymbolSet(c rune) rune {
+	switch {
+	case c >= baconSymbolUpper && c < baconSymbolUpper+26:
+		return 'A' + c - baconSymbolUpper
+	case c >= baconSymbolLower && c < baconSymbolLower+26:
+		return 'a' + c - baconSymbolLower
+	}
+	return 0
+}
+
+func toSymbolSet(c rune) rune {
+	if unicode.IsUpper(c) {
+		return baconSymbolUpper + c - 'A'
+	}
+	return baconSymbolLower + c - 'a'
+}
+
+// Returned when a carrier text has fewer letters than the hidden message
+// needs.
+type CarrierTooShortError struct {
+	Needed    int
+	Available int
+}
+
+func (e *CarrierTooShortError) Error() string {
+	return fmt.Sprintf(
+		"carrier too short: need %d letters, have %d", e.Needed, e.Available,
+	)
+}
+
+// Position of the letter in the Bacon alphabet, 0 to 25 or 0 to 23.
+func (b *Bacon) value(c rune) int {
+	v := int(c - 'A')
+	if b.distinct {
+		return v
+	}
+	switch {
+	case c >= 'V':
+		return v - 2
+	case c >= 'J':
+		return v - 1
+	default:
+		return v
+	}
+}
+
+func (b *Bacon) letter(v int) (rune, error) {
+	size := 24
+	if b.distinct {
+		size = 26
+	}
+	if v < 0 || v >= size {
+		return 0, fmt.Errorf("no letter for group %05b", v)
+	}
+
+	c := rune('A' + v)
+	if !b.distinct {
+		switch {
+		case v >= 20:
+			c += 2
+		case v >= 9:
+			c++
+		}
+	}
+	return c, nil
+}
+
+// Message as a sequence of bits, false for `A` and true for `B`.
+func (b *Bacon) bits(s string) []bool {
+	str := prepareInput(s)
+	bits := make([]bool, 0, len(str)*5)
+	for _, c := range str {
+		v := b.value(c)
+		for i := 4; i >= 0; i-- {
+			bits = append(bits, v&(1<<i) != 0)
+		}
+	}
+	return bits
+}
+
+func (b *Bacon) fromBits(bits []bool) (string, error) {
+	if len(bits)%5 != 0 {
+		return "", errors.New("incomplete group of five")
+	}
+
+	letters := make([]rune, 0, len(bits)/5)
+	for i := 0; i < len(bits); i += 5 {
+		v := 0
+		for _, bit := range bits[i : i+5] {
+			v <<= 1
+			if bit {
+				v |= 1
+			}
+		}
+		c, err := b.letter(v)
+		if err != nil {
+			return "", err
+		}
+		letters = append(letters, c)
+	}
+
+	return string(letters), nil
+}
+
+// Encode returns the groups of five symbols separated by spaces.
+func (b *Bacon) Encode(s string) (string, error) {
+	bits := b.bits(s)
+	groups := make([]string, 0, len(bits)/5)
+
+	for i := 0; i < len(bits); i += 5 {
+		group := make([]rune, 5)
+		for j, bit := range bits[i : i+5] {
+			if bit {
+				group[j] = b.symbols[1]
+			} else {
+				group[j] = b.symbols[0]
+			}
+		}
+		groups = append(groups, string(group))
+	}
+
+	return strings.Join(groups, " "), nil
+}
+
+// Decode ignores anything but the two symbols.
+func (b *Bacon) Decode(s string) (string, error) {
+	bits := []bool{}
+	for _, c := range s {
+		switch c {
+		case b.symbols[0]:
+			bits = append(bits, false)
+		case b.symbols[1]:
+			bits = append(bits, true)
+		}
+	}
+
+	return b.fromBits(bits)
+}
+
+// Hide restyles the letters of `carrier` to carry the message and the
+// group that ends it. Carrier letters after that are left as `A`s.
+func (b *Bacon) Hide(s string, carrier string, style CarrierStyle) (string, error) {
+	bits := b.bits(s)
+	for i := 4; i >= 0; i-- {
+		bits = append(bits, baconEndGroup&(1<<i) != 0)
+	}
+	available := 0
+	for _, c := range carrier {
+		if carriesBit(c, style) {
+			available++
+		}
+	}
+	if available < len(bits) {
+		return "", &CarrierTooShortError{Needed: len(bits), Available: available}
+	}
+
+	var hidden strings.Builder
+	i := 0
+	for _, c := range carrier {
+		if !carriesBit(c, style) {
+			hidden.WriteRune(c)
+			continue
+		}
+
+		isB := i < len(bits) && bits[i]
+		i++
+
+		switch style {
+		case CaseStyle:
+			if isB {
+				hidden.WriteRune(unicode.ToUpper(c))
+			} else {
+				hidden.WriteRune(unicode.ToLower(c))
+			}
+		case MarkerStyle:
+			if isB {
+				hidden.WriteRune(baconMarker)
+				hidden.WriteRune(c)
+				hidden.WriteRune(baconMarker)
+			} else {
+				hidden.WriteRune(c)
+			}
+		case SymbolSetStyle:
+			if plain := fromSymbolSet(c); plain != 0 {
+				c = plain
+			}
+			if isB {
+				hidden.WriteRune(toSymbolSet(c))
+			} else {
+				hidden.WriteRune(c)
+			}
+		default:
+			return "", errors.New("unknown carrier style")
+		}
+	}
+
+	return hidden.String(), nil
+}
+
+// Extract reads the message back out of a carrier text produced by
+// Hide, up to the group that ends it. Without one, carrier letters left
+// over after the message read as trailing `A`s, and any that don't make
+// up a whole group are ignored.
+func (b *Bacon) Extract(text string, style CarrierStyle) (string, error) {
+	bits := []bool{}
+	marked := false
+
+	for _, c := range text {
+		switch {
+		case style == MarkerStyle && c == baconMarker:
+			marked = !marked
+		case !carriesBit(c, style):
+			continue
+		case style == CaseStyle:
+			bits = append(bits, unicode.IsUpper(c))
+		case style == MarkerStyle:
+			bits = append(bits, marked)
+		case style == SymbolSetStyle:
+			bits = append(bits, fromSymbolSet(c) != 0)
+		default:
+			return "", errors.New("unknown carrier style")
+		}
+	}
+
+	bits = bits[:len(bits)-len(bits)%5]
+	for i := 0; i < len(bits); i += 5 {
+		if !slices.Contains(bits[i:i+5], false) {
+			bits = bits[:i]
+			break
+		}
+	}
+	return b.fromBits(bits)
+}
+
+// `distinct` selects the 26 letter alphabet, otherwise I/J and U/V share
+// a group as in Bacon's original. The groups are written with `a` and
+// `b` for the two symbols, e.g. `A` and `B`, or `0` and `1`.
+func NewBacon(distinct bool, a rune, b rune) *Bacon {
+	return &Bacon{
+		distinct: distinct,
+		symbols:  [2]rune{a, b},
+	}
+}
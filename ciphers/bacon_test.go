@@ -0,0 +1,144 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type baconCase struct {
+	bacon   *Bacon
+	plain   string
+	encoded string
+	decoded string
+}
+
+type baconHideCase struct {
+	style   CarrierStyle
+	carrier string
+	hidden  string
+}
+
+type BaconTest struct {
+	suite.Suite
+	cases     []*baconCase
+	hideCases []*baconHideCase
+}
+
+func (suite *BaconTest) SetupTest() {
+	suite.cases = []*baconCase{
+		{
+			bacon:   NewBacon(false, 'A', 'B'),
+			plain:   "Steganography",
+			encoded: "BAAAB BAABA AABAA AABBA AAAAA ABBAA ABBAB AABBA BAAAA AAAAA ABBBA AABBB BABBA",
+			decoded: "STEGANOGRAPHY",
+		},
+		// I/J and U/V share groups in the 24 letter alphabet
+		{
+			bacon:   NewBacon(false, 'A', 'B'),
+			plain:   "jive",
+			encoded: "ABAAA ABAAA BAABB AABAA",
+			decoded: "IIUE",
+		},
+		{
+			bacon:   NewBacon(true, '0', '1'),
+			plain:   "jive",
+			encoded: "01001 01000 10101 00100",
+			decoded: "JIVE",
+		},
+	}
+
+	suite.hideCases = []*baconHideCase{
+		{
+			style:   CaseStyle,
+			carrier: "Nothing to see here, move along.",
+			hidden:  "noTHInG to sEE HERe, move along.",
+		},
+		{
+			style:   MarkerStyle,
+			carrier: "Nothing to see here, move along.",
+			hidden:  "No*t**h**i*n*g* to s*e**e* *h**e**r*e, move along.",
+		},
+		// only A to Z carry the message, so the ï is passed over
+		{
+			style:   SymbolSetStyle,
+			carrier: "Naïve things to see here, move along.",
+			hidden:  "Naï\U0001D5CF\U0001D5BE \U0001D5CDh\U0001D5C2ngs \U0001D5CD\U0001D5C8 \U0001D5CC\U0001D5BE\U0001D5BE here, move along.",
+		},
+	}
+}
+
+func (suite *BaconTest) TestEncoding() {
+	for _, cs := range suite.cases {
+		enc, err := cs.bacon.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *BaconTest) TestDecoding() {
+	for _, cs := range suite.cases {
+		dec, err := cs.bacon.Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.decoded, dec)
+	}
+}
+
+func (suite *BaconTest) TestHide() {
+	bc := NewBacon(true, 'A', 'B')
+	for _, cs := range suite.hideCases {
+		hidden, err := bc.Hide("hi", cs.carrier, cs.style)
+		suite.Nil(err)
+		suite.Equal(cs.hidden, hidden)
+	}
+}
+
+func (suite *BaconTest) TestExtract() {
+	bc := NewBacon(true, 'A', 'B')
+	for _, cs := range suite.hideCases {
+		extracted, err := bc.Extract(cs.hidden, cs.style)
+		suite.Nil(err)
+		suite.Equal("HI", extracted)
+	}
+
+	// without an end group, the rest of the carrier reads as `A`s
+	extracted, err := bc.Extract("noTHInG to see here", CaseStyle)
+	suite.Nil(err)
+	suite.Equal("HIA", extracted)
+
+	// letters without case can't carry a bit, so are passed over
+	hidden, err := bc.Hide("hi", "שלום nothing to see here", CaseStyle)
+	suite.Nil(err)
+	suite.True(strings.HasPrefix(hidden, "שלום noTHInG"))
+	extracted, err = bc.Extract(hidden, CaseStyle)
+	suite.Nil(err)
+	suite.Equal("HI", extracted)
+}
+
+func (suite *BaconTest) TestErrors() {
+	bc := NewBacon(false, 'A', 'B')
+	_, err := bc.Hide("hidden message", "too short", CaseStyle)
+	suite.NotNil(err)
+	suite.Equal("carrier too short: need 70 letters, have 8", err.Error())
+
+	tooShort := &CarrierTooShortError{}
+	suite.ErrorAs(err, &tooShort)
+	suite.Equal(70, tooShort.Needed)
+
+	_, err = bc.Hide("hi", "Naïve text", SymbolSetStyle)
+	suite.Equal("carrier too short: need 15 letters, have 8", err.Error())
+	_, err = bc.Hide("hi", "שלום עולם and more", CaseStyle)
+	suite.Equal("carrier too short: need 15 letters, have 7", err.Error())
+
+	_, err = bc.Decode("AAAAA BB")
+	suite.NotNil(err)
+	suite.Equal("incomplete group of five", err.Error())
+
+	_, err = bc.Decode("BBBBB")
+	suite.NotNil(err)
+	suite.Equal("no letter for group 11111", err.Error())
+}
+
+func TestBacon(t *testing.T) {
+	suite.Run(t, new(BaconTest))
+}
@@ -0,0 +1,247 @@
+package ciphers
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// https://en.wikipedia.org/wiki/Book_cipher
+//
+// Words or letters of the message are replaced by references to where
+// they appear in a book both sides hold, either as page/line/word
+// (Ottendorf) references or as the word's position in the whole book.
+type BookCipher struct {
+	index  *BookIndex
+	refs   BookRefStyle
+	unit   BookUnit
+	choice OccurrenceChoice
+	rng    *rand.Rand
+	Encoder
+	Decoder
+}
+
+// A word in the book, with its 1-based page, line and word position.
+type BookWord struct {
+	Page int
+	Line int
+	Word int
+	// upper case, letters only
+	Text string
+}
+
+// Where every word of a book appears. Building one is the slow part, so
+// it can be shared by any number of BookCiphers.
+type BookIndex struct {
+	// every word in reading order
+	words []BookWord
+	// positions in `words` of each word, and of words starting with each
+	// letter
+	byWord   map[string][]int
+	byLetter map[rune][]int
+	// position in `words` of each page/line/word reference
+	byRef map[[3]int]int
+}
+
+type BookRefStyle int
+
+const (
+	// `page.line.word`
+	OttendorfRefs BookRefStyle = iota
+	// the word's number counting from the start of the book
+	WordIndexRefs
+)
+
+type BookUnit int
+
+const (
+	// whole words are looked up
+	WordUnit BookUnit = iota
+	// each letter is replaced by a word starting with it, as in the
+	// Beale ciphers
+	LetterUnit
+)
+
+// Which occurrence of a word is used when it appears more than once.
+type OccurrenceChoice int
+
+const (
+	FirstOccurrence OccurrenceChoice = iota
+	RandomOccurrence
+)
+
+// Indexes the words of `text`. Pages are separated by form feeds, or
+// when there are none, every `linesPerPage` lines make a page. With no
+// form feeds and `linesPerPage` of 0, the book is a single page.
+// Blank lines count as lines, and tokens without letters aren't words.
+func NewBookIndex(text string, linesPerPage int) *BookIndex {
+	index := &BookIndex{
+		byWord:   map[string][]int{},
+		byLetter: map[rune][]int{},
+		byRef:    map[[3]int]int{},
+	}
+
+	pages := strings.Split(text, "\f")
+	for i := 1; i < len(pages); i++ {
+		// a form feed usually ends its line, the next page starts after it
+		pages[i] = strings.TrimPrefix(pages[i], "\n")
+	}
+	if len(pages) == 1 && linesPerPage > 0 {
+		lines := strings.Split(text, "\n")
+		pages = []string{}
+		for i := 0; i < len(lines); i += linesPerPage {
+			pages = append(pages, strings.Join(lines[i:min(i+linesPerPage, len(lines))], "\n"))
+		}
+	}
+
+	for p, page := range pages {
+		for l, line := range strings.Split(page, "\n") {
+			w := 0
+			for _, token := range strings.Fields(line) {
+				word := prepareInput(token)
+				if len(word) == 0 {
+					continue
+				}
+				w++
+
+				pos := len(index.words)
+				index.words = append(index.words, BookWord{
+					Page: p + 1,
+					Line: l + 1,
+					Word: w,
+					Text: word,
+				})
+				index.byWord[word] = append(index.byWord[word], pos)
+				first := rune(word[0])
+				index.byLetter[first] = append(index.byLetter[first], pos)
+				index.byRef[[3]int{p + 1, l + 1, w}] = pos
+			}
+		}
+	}
+
+	return index
+}
+
+// Number of words in the book.
+func (b *BookIndex) Len() int {
+	return len(b.words)
+}
+
+func (b *BookCipher) pick(positions []int) int {
+	if b.choice == RandomOccurrence {
+		return positions[b.rng.Intn(len(positions))]
+	}
+	return positions[0]
+}
+
+func (b *BookCipher) ref(pos int) string {
+	if b.refs == WordIndexRefs {
+		return strconv.Itoa(pos + 1)
+	}
+	w := b.index.words[pos]
+	return fmt.Sprintf("%d.%d.%d", w.Page, w.Line, w.Word)
+}
+
+func (b *BookCipher) lookupRef(ref string) (BookWord, error) {
+	if b.refs == WordIndexRefs {
+		n, err := strconv.Atoi(ref)
+		if err != nil || n < 1 || n > len(b.index.words) {
+			return BookWord{}, fmt.Errorf("no word %s in book", ref)
+		}
+		return b.index.words[n-1], nil
+	}
+
+	parts := strings.Split(ref, ".")
+	if len(parts) != 3 {
+		return BookWord{}, fmt.Errorf("expected page.line.word reference: %s", ref)
+	}
+	key := [3]int{}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return BookWord{}, fmt.Errorf("expected page.line.word reference: %s", ref)
+		}
+		key[i] = n
+	}
+	pos, ok := b.index.byRef[key]
+	if !ok {
+		return BookWord{}, fmt.Errorf("no word %s in book", ref)
+	}
+	return b.index.words[pos], nil
+}
+
+// Encode returns the references separated by spaces.
+func (b *BookCipher) Encode(s string) (string, error) {
+	refs := []string{}
+
+	switch b.unit {
+	case LetterUnit:
+		for _, c := range prepareInput(s) {
+			positions, ok := b.index.byLetter[c]
+			if !ok {
+				return "", fmt.Errorf("no word in book starting with: %s", string(c))
+			}
+			refs = append(refs, b.ref(b.pick(positions)))
+		}
+	default:
+		for _, token := range strings.Fields(s) {
+			word := prepareInput(token)
+			if len(word) == 0 {
+				continue
+			}
+			positions, ok := b.index.byWord[word]
+			if !ok {
+				return "", fmt.Errorf("word not in book: %s", word)
+			}
+			refs = append(refs, b.ref(b.pick(positions)))
+		}
+	}
+
+	return strings.Join(refs, " "), nil
+}
+
+// Decode expects whitespace separated references, as produced by Encode.
+func (b *BookCipher) Decode(s string) (string, error) {
+	refs := strings.Fields(s)
+	words := make([]string, len(refs))
+
+	for i, ref := range refs {
+		word, err := b.lookupRef(ref)
+		if err != nil {
+			return "", err
+		}
+		if b.unit == LetterUnit {
+			words[i] = word.Text[:1]
+		} else {
+			words[i] = word.Text
+		}
+	}
+
+	if b.unit == LetterUnit {
+		return strings.Join(words, ""), nil
+	}
+	return strings.Join(words, " "), nil
+}
+
+// Random occurrences are chosen with a generator seeded with `seed`.
+func NewBookCipher(
+	index *BookIndex,
+	refs BookRefStyle,
+	unit BookUnit,
+	choice OccurrenceChoice,
+	seed int64,
+) (*BookCipher, error) {
+	if index == nil || index.Len() == 0 {
+		return nil, errors.New("book has no words")
+	}
+
+	return &BookCipher{
+		index:  index,
+		refs:   refs,
+		unit:   unit,
+		choice: choice,
+		rng:    rand.New(rand.NewSource(seed)),
+	}, nil
+}
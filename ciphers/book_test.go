@@ -0,0 +1,118 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+const testBook = `When in the course of human events,
+it becomes necessary for one people
+to dissolve the political bands
+which have connected them with another.
+`
+
+type BookTest struct {
+	suite.Suite
+	index *BookIndex
+}
+
+func (suite *BookTest) SetupTest() {
+	suite.index = NewBookIndex(testBook, 2)
+}
+
+func (suite *BookTest) TestIndex() {
+	suite.Equal(24, suite.index.Len())
+	suite.Equal(BookWord{Page: 1, Line: 1, Word: 1, Text: "WHEN"}, suite.index.words[0])
+	// two lines per page
+	suite.Equal(BookWord{Page: 2, Line: 1, Word: 1, Text: "TO"}, suite.index.words[13])
+
+	// form feeds take precedence over lines per page
+	paged := NewBookIndex("one two\nthree\fFOUR", 1)
+	suite.Equal(BookWord{Page: 1, Line: 2, Word: 1, Text: "THREE"}, paged.words[2])
+	suite.Equal(BookWord{Page: 2, Line: 1, Word: 1, Text: "FOUR"}, paged.words[3])
+
+	// a blank line starting a page is still its first line
+	blank := NewBookIndex("alpha beta\ngamma\n\ndelta epsilon\nzeta", 2)
+	suite.Equal(BookWord{Page: 2, Line: 2, Word: 1, Text: "DELTA"}, blank.words[3])
+	bc, err := NewBookCipher(blank, OttendorfRefs, WordUnit, FirstOccurrence, 0)
+	suite.Nil(err)
+	enc, err := bc.Encode("delta")
+	suite.Nil(err)
+	suite.Equal("2.2.1", enc)
+}
+
+func (suite *BookTest) TestOttendorf() {
+	bc, err := NewBookCipher(suite.index, OttendorfRefs, WordUnit, FirstOccurrence, 0)
+	suite.Nil(err)
+
+	enc, err := bc.Encode("The people, with one political human!")
+	suite.Nil(err)
+	suite.Equal("1.1.3 1.2.6 2.2.5 1.2.5 2.1.4 1.1.6", enc)
+
+	dec, err := bc.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("THE PEOPLE WITH ONE POLITICAL HUMAN", dec)
+}
+
+func (suite *BookTest) TestWordIndex() {
+	bc, err := NewBookCipher(suite.index, WordIndexRefs, WordUnit, FirstOccurrence, 0)
+	suite.Nil(err)
+
+	enc, err := bc.Encode("the people")
+	suite.Nil(err)
+	suite.Equal("3 13", enc)
+
+	dec, err := bc.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("THE PEOPLE", dec)
+}
+
+func (suite *BookTest) TestLetters() {
+	bc, err := NewBookCipher(suite.index, WordIndexRefs, LetterUnit, RandomOccurrence, 7)
+	suite.Nil(err)
+
+	enc, err := bc.Encode("tow hit")
+	suite.Nil(err)
+
+	dec, err := bc.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("TOWHIT", dec)
+
+	// the same seed makes the same choices
+	again, err := NewBookCipher(suite.index, WordIndexRefs, LetterUnit, RandomOccurrence, 7)
+	suite.Nil(err)
+	againEnc, err := again.Encode("tow hit")
+	suite.Nil(err)
+	suite.Equal(enc, againEnc)
+}
+
+func (suite *BookTest) TestErrors() {
+	_, err := NewBookCipher(NewBookIndex("", 0), OttendorfRefs, WordUnit, FirstOccurrence, 0)
+	suite.NotNil(err)
+	suite.Equal("book has no words", err.Error())
+
+	bc, err := NewBookCipher(suite.index, OttendorfRefs, WordUnit, FirstOccurrence, 0)
+	suite.Nil(err)
+
+	_, err = bc.Encode("independence")
+	suite.NotNil(err)
+	suite.Equal("word not in book: INDEPENDENCE", err.Error())
+
+	_, err = bc.Decode("1.1.1 9.9.9")
+	suite.NotNil(err)
+	suite.Equal("no word 9.9.9 in book", err.Error())
+
+	_, err = bc.Decode("1.1")
+	suite.NotNil(err)
+	suite.Equal("expected page.line.word reference: 1.1", err.Error())
+
+	bc, err = NewBookCipher(suite.index, WordIndexRefs, LetterUnit, FirstOccurrence, 0)
+	suite.Nil(err)
+	_, err = bc.Encode("z")
+	suite.NotNil(err)
+	suite.Equal("no word in book starting with: Z", err.Error())
+}
+
+func TestBook(t *testing.T) {
+	suite.Run(t, new(BookTest))
+}
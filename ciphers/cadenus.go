@@ -0,0 +1,107 @@
+package ciphers
+
+import (
+	"errors"
+	"fmt"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"strings"
+)
+
+// https://www.cryptogram.org/resource-area/cipher-types/
+//
+// The message is written in rows under the key, 25 rows deep, and the
+// columns are put in the key's alphabetical order. Each column is then
+// turned vertically until the row its key letter marks in the sequence
+// `AZYXVUTSRQPONMLKJIHGFEDCB` is at the top, and the rows are read off.
+type Cadenus struct {
+	key string
+	// letters kept from the message
+	alphabet *lookup.Alphabet
+	Encoder
+	Decoder
+}
+
+const (
+	cadenusRows     = 25
+	cadenusAlphabet = "AZYXVUTSRQPONMLKJIHGFEDCB"
+)
+
+// The key's letters with W read as V, since the row markers have no W.
+func (c *Cadenus) prepareKey() (string, error) {
+	key := strings.ReplaceAll(prepareInput(c.key), "W", "V")
+	if len(key) == 0 {
+		return "", errors.New("empty key")
+	}
+	return key, nil
+}
+
+// Moves the letters of a block into their enciphered positions, or back
+// again when `reverse` is set.
+func (c *Cadenus) transposeBlock(block []rune, key string, reverse bool) []rune {
+	width := len(key)
+	order := keyOrder(key)
+	out := make([]rune, len(block))
+
+	for col, letter := range key {
+		shift := strings.IndexRune(cadenusAlphabet, letter)
+		for row := 0; row < cadenusRows; row++ {
+			from := row*width + col
+			to := ((row-shift+cadenusRows)%cadenusRows)*width + order[col]
+			if reverse {
+				from, to = to, from
+			}
+			out[to] = block[from]
+		}
+	}
+	return out
+}
+
+// Encode pads the message with `X`, or the last letter of an alphabet
+// without one, to a multiple of 25 times the key length, and enciphers
+// each block of that size in turn.
+func (c *Cadenus) Encode(s string) (string, error) {
+	key, err := c.prepareKey()
+	if err != nil {
+		return "", err
+	}
+
+	size := cadenusRows * len(key)
+	str := []rune(c.alphabet.Filter(s))
+	for len(str)%size != 0 || len(str) == 0 {
+		str = append(str, paddingLetter(c.alphabet))
+	}
+
+	encoded := make([]rune, 0, len(str))
+	for i := 0; i < len(str); i += size {
+		encoded = append(encoded, c.transposeBlock(str[i:i+size], key, false)...)
+	}
+	return string(encoded), nil
+}
+
+func (c *Cadenus) Decode(s string) (string, error) {
+	key, err := c.prepareKey()
+	if err != nil {
+		return "", err
+	}
+
+	size := cadenusRows * len(key)
+	str := []rune(c.alphabet.Filter(s))
+	if len(str)%size != 0 {
+		return "", fmt.Errorf("ciphertext length must be a multiple of %d", size)
+	}
+
+	decoded := make([]rune, 0, len(str))
+	for i := 0; i < len(str); i += size {
+		decoded = append(decoded, c.transposeBlock(str[i:i+size], key, true)...)
+	}
+	return string(decoded), nil
+}
+
+// The message can be in any alphabet, but the key is always A to Z,
+// since its letters pick rows by the fixed markers.
+func NewCadenus(key string, opts ...Option) *Cadenus {
+	return &Cadenus{
+		key:      key,
+		alphabet: newOptions(opts).alphabet,
+	}
+}
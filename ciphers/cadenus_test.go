@@ -0,0 +1,49 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type CadenusTest struct {
+	suite.Suite
+	cadenus *Cadenus
+	plain   string
+	encoded string
+}
+
+func (suite *CadenusTest) SetupTest() {
+	// the ACA's example
+	suite.cadenus = NewCadenus("EASY")
+	suite.plain = "A severe limitation on the usefulness of the Cadenus is that every message must be a multiple of twenty-five letters long."
+	suite.encoded = "SYSTRETOMTATTLUSOATLEEESFIYHEASDFNMSCHBHNEUVSNPMTOFARENUSEIEEIELTARLMENTIEETOGEVESITFAISLTNGEEUVOWUL"
+}
+
+func (suite *CadenusTest) TestEncoding() {
+	enc, err := suite.cadenus.Encode(suite.plain)
+	suite.Nil(err)
+	suite.Equal(suite.encoded, enc)
+
+	// short messages are padded to a whole block
+	enc, err = NewCadenus("W").Encode("ab")
+	suite.Nil(err)
+	suite.Equal("XXXXXXXXXXXXXXXXXXXXXABXX", enc)
+}
+
+func (suite *CadenusTest) TestDecoding() {
+	dec, err := suite.cadenus.Decode(suite.encoded)
+	suite.Nil(err)
+	suite.Equal(prepareInput(suite.plain), dec)
+}
+
+func (suite *CadenusTest) TestErrors() {
+	_, err := NewCadenus("").Encode(suite.plain)
+	suite.EqualError(err, "empty key")
+
+	_, err = suite.cadenus.Decode("SYSTRETOMT")
+	suite.EqualError(err, "ciphertext length must be a multiple of 100")
+}
+
+func TestCadenus(t *testing.T) {
+	suite.Run(t, new(CadenusTest))
+}
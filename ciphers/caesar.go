@@ -1,57 +1,27 @@
 package ciphers
 
 import (
+	"cmp"
 	"errors"
+	analysis "github.com/ubermensch/ciphers/analysis"
 	lookup "github.com/ubermensch/ciphers/lookup"
-	"sync"
+	"slices"
 )
 
 type Caesar struct {
-	offset    int
-	lowerRing *lookup.AlphaRing
-	upperRing *lookup.AlphaRing
+	offset int
+	polyalphabetic
 	Encoder
 	Decoder
 }
 
-func (c *Caesar) encodeChar(b rune) (rune, error) {
-	var encoded rune
-	var err error
-
-	switch {
-	case c.lowerRing.Contains(b):
-		encoded, err = c.lowerRing.Move(b, c.offset)
-	case c.upperRing.Contains(b):
-		encoded, err = c.upperRing.Move(b, c.offset)
-	default:
-		encoded, err = b, nil
-	}
-
-	if err != nil {
-		return 0, err
-	}
-
-	return encoded, nil
-}
-
-func (c *Caesar) decodeChar(b rune) (rune, error) {
-	var decoded rune
-	var err error
-
-	switch {
-	case c.lowerRing.Contains(b):
-		decoded, err = c.lowerRing.Move(b, -c.offset)
-	case c.upperRing.Contains(b):
-		decoded, err = c.upperRing.Move(b, -c.offset)
-	default:
-		decoded, err = b, nil
-	}
-
-	if err != nil {
-		return 0, err
-	}
-
-	return decoded, nil
+// A possible decoding found by BruteForceCaesar.
+type CaesarCandidate struct {
+	Offset    int
+	Plaintext string
+	// chi-squared against English letter frequencies, negated so that
+	// higher is more like English
+	Score float64
 }
 
 func (c *Caesar) Encode(s string) (string, error) {
@@ -59,32 +29,15 @@ func (c *Caesar) Encode(s string) (string, error) {
 		return "", errors.New("expected positive integer offset")
 	}
 
-	runes := make([]rune, len(s))
-	wg := sync.WaitGroup{}
-	errCount := 0
-
 	// encode each character in parallel
-	encFunc := func(r rune, pos int, wg *sync.WaitGroup) {
-		defer wg.Done()
-		enc, err := c.encodeChar(r)
-
-		if err != nil {
-			errCount++
-		}
-		runes[pos] = enc
-	}
-
-	for i, curr := range s {
-		wg.Add(1)
-		go encFunc(curr, i, &wg)
-	}
-
-	wg.Wait()
-	if errCount > 0 {
+	encoded, err := c.transform(s, func(r rune, pos int) (rune, error) {
+		return c.shiftChar(r, c.offset)
+	})
+	if err != nil {
 		return "", errors.New("encoding failed")
 	}
 
-	return string(runes), nil
+	return encoded, nil
 }
 
 func (c *Caesar) Decode(s string) (string, error) {
@@ -92,37 +45,44 @@ func (c *Caesar) Decode(s string) (string, error) {
 		return "", errors.New("expected positive integer offset")
 	}
 
-	runes := make([]rune, len(s))
-	wg := sync.WaitGroup{}
-	errCount := 0
-
-	decFunc := func(r rune, pos int, wg *sync.WaitGroup) {
-		defer wg.Done()
-		dec, err := c.decodeChar(r)
-		if err != nil {
-			errCount++
-		}
-
-		runes[pos] = dec
+	decoded, err := c.transform(s, func(r rune, pos int) (rune, error) {
+		return c.shiftChar(r, -c.offset)
+	})
+	if err != nil {
+		return "", errors.New("decoding failed")
 	}
 
-	for i, curr := range s {
-		wg.Add(1)
-		go decFunc(curr, i, &wg)
-	}
+	return decoded, nil
+}
 
-	wg.Wait()
-	if errCount > 0 {
-		return "", errors.New("decoding failed")
+// Decodes `s` with every offset the alphabet allows, and returns the
+// results best first. Scores only mean anything for English, but other
+// alphabets are still decoded.
+func BruteForceCaesar(s string, opts ...Option) []CaesarCandidate {
+	alphabet := newOptions(opts).alphabet
+	letters := alphabet.Filter(s)
+	candidates := make([]CaesarCandidate, 0, alphabet.Len()-1)
+
+	for offset := 1; offset < alphabet.Len(); offset++ {
+		// Decode can't fail with a positive offset
+		plain, _ := NewCaesar(offset, opts...).Decode(s)
+		scored, _ := NewCaesar(offset, opts...).Decode(letters)
+		candidates = append(candidates, CaesarCandidate{
+			Offset:    offset,
+			Plaintext: plain,
+			Score:     -analysis.ChiSquared(scored, lookup.EnglishFrequencies),
+		})
 	}
 
-	return string(runes), nil
+	slices.SortStableFunc(candidates, func(a, b CaesarCandidate) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return candidates
 }
 
-func NewCaesar(offset int) *Caesar {
+func NewCaesar(offset int, opts ...Option) *Caesar {
 	return &Caesar{
-		offset:    offset,
-		lowerRing: lookup.NewAlphaRing(true),
-		upperRing: lookup.NewAlphaRing(false),
+		offset:         offset,
+		polyalphabetic: newPolyalphabetic(newOptions(opts).alphabet),
 	}
 }
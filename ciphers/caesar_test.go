@@ -2,6 +2,7 @@ package ciphers
 
 import (
 	"github.com/stretchr/testify/suite"
+	lookup "github.com/ubermensch/ciphers/lookup"
 	"testing"
 )
 
@@ -99,6 +100,23 @@ func (suite *CaesarTest) TestErrors() {
 	suite.Equal("expected positive integer offset", err.Error())
 }
 
+func (suite *CaesarTest) TestBruteForce() {
+	plain := "Meet me by the old oak tree at midnight."
+	for offset := 1; offset < 26; offset++ {
+		enc, err := NewCaesar(offset).Encode(plain)
+		suite.Nil(err)
+
+		candidates := BruteForceCaesar(enc)
+		suite.Len(candidates, 25)
+		suite.Equal(offset, candidates[0].Offset)
+		suite.Equal(plain, candidates[0].Plaintext)
+		suite.Greater(candidates[0].Score, candidates[1].Score)
+	}
+
+	// every offset of a Greek message is tried
+	suite.Len(BruteForceCaesar("αβγ", WithAlphabet(lookup.Greek)), 23)
+}
+
 func TestCaesar(t *testing.T) {
 	suite.Run(t, new(CaesarTest))
 }
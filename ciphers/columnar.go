@@ -0,0 +1,374 @@
+package ciphers
+
+import (
+	"errors"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	ngram "github.com/ubermensch/ciphers/ngram"
+	"math"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// https://en.wikipedia.org/wiki/Transposition_cipher#Columnar_transposition
+//
+// The message is written in rows under the key, and the columns are read
+// off top to bottom in the alphabetical order of their key letters.
+// Without padding the last row is left short, which makes the grid
+// irregular.
+type Columnar struct {
+	// numeric order of the key letters: the column read first is 0
+	order []int
+	// written into the empty places of the last row, or 0 to leave them
+	padding rune
+	// letters kept from the message
+	alphabet *lookup.Alphabet
+	Encoder
+	Decoder
+}
+
+// A key found by SolveColumnar.
+type ColumnarSolution struct {
+	KeyLength int
+	// when each column is read, as the key's letters would give it: the
+	// column holding 0 is read first
+	Order []int
+	// letters A onwards in the order of `Order`, ready for NewColumnar
+	Key string
+	// the message written out under the key, one row per line
+	Grid      []string
+	Plaintext string
+	// higher is more like English
+	Score float64
+}
+
+// Longest key SolveColumnar tries when no key length is given.
+const columnarMaxKeyLength = 15
+
+// Longest key whose column order SolveColumnar finds exactly from
+// letter pairs. Beyond it, hill climbing takes over.
+const columnarMaxAdjacency = 12
+
+// How many letters each column holds, in a message of `length` letters
+// written in rows of `columns`.
+func columnLengths(length int, columns int) []int {
+	lengths := make([]int, columns)
+	for c := range lengths {
+		lengths[c] = length / columns
+		if c < length%columns {
+			lengths[c]++
+		}
+	}
+	return lengths
+}
+
+// Reverses the transposition for the column read order `order`, writing
+// the message into `plain`. Works on any values, so solvers can pass
+// letters as numbers.
+func uncolumnar[T any](cipher []T, order []int, plain []T) {
+	columns := len(order)
+	lengths := columnLengths(len(cipher), columns)
+	byRank := make([]int, columns)
+	for c, rank := range order {
+		byRank[rank] = c
+	}
+
+	i := 0
+	for _, c := range byRank {
+		for r := 0; r < lengths[c]; r++ {
+			plain[r*columns+c] = cipher[i]
+			i++
+		}
+	}
+}
+
+// Encode pads the last row when padding is set.
+func (col *Columnar) Encode(s string) (string, error) {
+	if len(col.order) < 2 {
+		return "", errors.New("key must have at least 2 letters")
+	}
+
+	plain := []rune(col.alphabet.Filter(s))
+	if col.padding != 0 {
+		for len(plain)%len(col.order) != 0 {
+			plain = append(plain, col.padding)
+		}
+	}
+
+	columns := len(col.order)
+	byRank := make([]int, columns)
+	for c, rank := range col.order {
+		byRank[rank] = c
+	}
+	encoded := make([]rune, 0, len(plain))
+	for _, c := range byRank {
+		for i := c; i < len(plain); i += columns {
+			encoded = append(encoded, plain[i])
+		}
+	}
+	return string(encoded), nil
+}
+
+// Decode leaves any padding in place.
+func (col *Columnar) Decode(s string) (string, error) {
+	if len(col.order) < 2 {
+		return "", errors.New("key must have at least 2 letters")
+	}
+
+	cipher := []rune(col.alphabet.Filter(s))
+	plain := make([]rune, len(cipher))
+	uncolumnar(cipher, col.order, plain)
+	return string(plain), nil
+}
+
+// Finds the column order from how well each column follows each other,
+// scoring the letter pairs they make across every full row against
+// English bigrams. When the grid is short, which columns were read long
+// isn't known, so every split of them into long and short is tried, with
+// the long columns kept to the left as the grid has them. The best
+// arrangement for each split is found exactly, by dynamic programming
+// over sets of columns already placed.
+func columnarAdjacency(cipher []int, columns int) []int {
+	rows := len(cipher) / columns
+	long := len(cipher) % columns
+	bigrams := ngram.EnglishBigrams()
+
+	// follows[i][j] scores the column read i-th just left of the one
+	// read j-th
+	follows := make([][]float64, columns)
+	for i := range follows {
+		follows[i] = make([]float64, columns)
+	}
+	// best[set][last] is the best score of a left-to-right run through
+	// the columns in `set` that ends with `last`
+	sets := 1 << columns
+	best := make([][]float64, sets)
+	from := make([][]int, sets)
+	for set := range best {
+		best[set] = make([]float64, columns)
+		from[set] = make([]int, columns)
+	}
+
+	order := make([]int, columns)
+	bestScore := math.Inf(-1)
+	start := make([]int, columns)
+	pair := make([]int, 2)
+	for split := 0; split < sets; split++ {
+		if bits.OnesCount(uint(split)) != long {
+			continue
+		}
+		// where each column starts in the ciphertext, reading the columns
+		// in `split` one letter longer
+		for i := 1; i < columns; i++ {
+			start[i] = start[i-1] + rows
+			if split&(1<<(i-1)) != 0 {
+				start[i]++
+			}
+		}
+		for i := range follows {
+			for j := range follows[i] {
+				follows[i][j] = 0
+				if i == j {
+					continue
+				}
+				for r := 0; r < rows; r++ {
+					pair[0], pair[1] = cipher[start[i]+r], cipher[start[j]+r]
+					follows[i][j] += bigrams.ScoreIndices(pair)
+				}
+			}
+		}
+
+		for set := range best {
+			for last := range best[set] {
+				best[set][last] = math.Inf(-1)
+			}
+		}
+		for c := 0; c < columns; c++ {
+			if (split&(1<<c) != 0) == (long > 0) {
+				best[1<<c][c] = 0
+			}
+		}
+		for set := 1; set < sets; set++ {
+			// the next column goes at this position, long if the grid's
+			// long columns aren't all placed yet
+			nextLong := bits.OnesCount(uint(set)) < long
+			for last := 0; last < columns; last++ {
+				if math.IsInf(best[set][last], -1) {
+					continue
+				}
+				for next := 0; next < columns; next++ {
+					if set&(1<<next) != 0 || (split&(1<<next) != 0) != nextLong {
+						continue
+					}
+					score := best[set][last] + follows[last][next]
+					if score > best[set|1<<next][next] {
+						best[set|1<<next][next] = score
+						from[set|1<<next][next] = last
+					}
+				}
+			}
+		}
+
+		last := 0
+		for c := range best[sets-1] {
+			if best[sets-1][c] > best[sets-1][last] {
+				last = c
+			}
+		}
+		if best[sets-1][last] <= bestScore {
+			continue
+		}
+		bestScore = best[sets-1][last]
+		// walk back from the right-hand column; the column at position p
+		// was read `last`-th, so that is its rank
+		for set, p := sets-1, columns-1; p >= 0; p-- {
+			order[p] = last
+			set, last = set&^(1<<last), from[set][last]
+		}
+	}
+	return order
+}
+
+// One hill climb over column orders, starting from `order` and keeping
+// any change that improves the decoding's score against English
+// quadgrams, until `iterations` changes in a row fail. Changes swap two
+// columns or move one to another place. `order` is left holding the
+// best order found.
+func climbColumnar(cipher []int, order []int, iterations int, rng *rand.Rand) float64 {
+	quadgrams := ngram.EnglishQuadgrams()
+	plain := make([]int, len(cipher))
+	decode := func() float64 {
+		uncolumnar(cipher, order, plain)
+		return quadgrams.ScoreIndices(plain)
+	}
+
+	best := decode()
+	saved := slices.Clone(order)
+	for failed := 0; failed < iterations; {
+		i, j := rng.Intn(len(order)), rng.Intn(len(order))
+		if i == j {
+			continue
+		}
+
+		copy(saved, order)
+		if rng.Intn(2) == 0 {
+			order[i], order[j] = order[j], order[i]
+		} else {
+			moved := order[i]
+			order = slices.Insert(slices.Delete(order, i, i+1), j, moved)
+		}
+
+		if score := decode(); score > best {
+			best = score
+			failed = 0
+		} else {
+			copy(order, saved)
+			failed++
+		}
+	}
+	return best
+}
+
+// Breaks a columnar transposition by trying each key length from 2 to
+// 15, or just `keyLength` when it is positive, and keeping the decoding
+// most like English. When the key is no longer than 12, the column
+// order comes straight from how well the columns' letter pairs follow
+// each other, and a hill climb then polishes it. Longer keys are found
+// by hill climbs from random orders, each restart going until
+// `Iterations` changes in a row fail. Restarts default to 10
+// and iterations to 1000.
+func SolveColumnar(s string, keyLength int, settings SolverSettings) (*ColumnarSolution, error) {
+	settings = settings.withDefaults(10, 1000)
+
+	cipher := []int{}
+	for _, c := range prepareInput(s) {
+		cipher = append(cipher, int(c-'A'))
+	}
+	if len(cipher) < 4 {
+		return nil, errors.New("not enough letters to solve")
+	}
+
+	lengths := []int{}
+	if keyLength > 0 {
+		if keyLength < 2 || keyLength > len(cipher)/2 {
+			return nil, errors.New("key length must be between 2 and half the message")
+		}
+		lengths = append(lengths, keyLength)
+	} else {
+		for k := 2; k <= columnarMaxKeyLength && k <= len(cipher)/2; k++ {
+			lengths = append(lengths, k)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(settings.Seed))
+	var solution *ColumnarSolution
+	for _, k := range lengths {
+		order := make([]int, k)
+		keep := func(restart int, score float64) {
+			if solution != nil && score <= solution.Score {
+				return
+			}
+			solution = newColumnarSolution(cipher, order, score)
+			settings.report(SolverProgress{
+				Restart:   restart,
+				Key:       solution.Key,
+				Plaintext: solution.Plaintext,
+				Score:     solution.Score,
+			})
+		}
+
+		if k <= columnarMaxAdjacency {
+			copy(order, columnarAdjacency(cipher, k))
+			keep(0, climbColumnar(cipher, order, settings.Iterations, rng))
+			continue
+		}
+		for restart := 0; restart < settings.Restarts; restart++ {
+			copy(order, rng.Perm(k))
+			keep(restart, climbColumnar(cipher, order, settings.Iterations, rng))
+		}
+	}
+
+	return solution, nil
+}
+
+func newColumnarSolution(cipher []int, order []int, score float64) *ColumnarSolution {
+	plain := make([]int, len(cipher))
+	uncolumnar(cipher, order, plain)
+
+	var text strings.Builder
+	for _, c := range plain {
+		text.WriteRune(rune('A' + c))
+	}
+	plaintext := text.String()
+
+	key := make([]rune, len(order))
+	for c, rank := range order {
+		key[c] = rune('A' + rank)
+	}
+
+	grid := []string{}
+	for i := 0; i < len(plaintext); i += len(order) {
+		grid = append(grid, plaintext[i:min(i+len(order), len(plaintext))])
+	}
+
+	return &ColumnarSolution{
+		KeyLength: len(order),
+		Order:     slices.Clone(order),
+		Key:       string(key),
+		Grid:      grid,
+		Plaintext: plaintext,
+		Score:     score,
+	}
+}
+
+// `padding` of 0 leaves the last row short. The key is written in the
+// cipher's alphabet.
+func NewColumnar(key string, padding rune, opts ...Option) *Columnar {
+	alphabet := newOptions(opts).alphabet
+	return &Columnar{
+		order:    alphabetKeyOrder(key, alphabet),
+		padding:  padding,
+		alphabet: alphabet,
+	}
+}
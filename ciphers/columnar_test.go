@@ -0,0 +1,104 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type columnarCase struct {
+	key     string
+	padding rune
+	plain   string
+	encoded string
+	decoded string
+}
+
+type ColumnarTest struct {
+	suite.Suite
+	cases []*columnarCase
+}
+
+func (suite *ColumnarTest) SetupTest() {
+	suite.cases = []*columnarCase{
+		// https://en.wikipedia.org/wiki/Transposition_cipher#Columnar_transposition
+		{
+			key:     "ZEBRAS",
+			plain:   "We are discovered. Flee at once",
+			encoded: "EVLNACDTESEAROFODEECWIREE",
+			decoded: "WEAREDISCOVEREDFLEEATONCE",
+		},
+		{
+			key:     "ZEBRAS",
+			padding: 'X',
+			plain:   "We are discovered. Flee at once",
+			encoded: "EVLNXACDTXESEAXROFOXDEECXWIREE",
+			decoded: "WEAREDISCOVEREDFLEEATONCEXXXXX",
+		},
+	}
+}
+
+func (suite *ColumnarTest) TestEncode() {
+	for _, cs := range suite.cases {
+		enc, err := NewColumnar(cs.key, cs.padding).Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *ColumnarTest) TestDecode() {
+	for _, cs := range suite.cases {
+		dec, err := NewColumnar(cs.key, cs.padding).Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.decoded, dec)
+	}
+}
+
+func (suite *ColumnarTest) TestSolve() {
+	want := prepareInput(kerckhoffs)
+
+	// the grid is short with seven columns and complete with five
+	for _, key := range []string{"GERMANY", "ZEBRA"} {
+		enc, err := NewColumnar(key, 0).Encode(kerckhoffs)
+		suite.Nil(err)
+
+		solution, err := SolveColumnar(enc, 0, SolverSettings{Seed: 1})
+		suite.Nil(err)
+		suite.Equal(want, solution.Plaintext)
+		suite.Equal(len(key), solution.KeyLength)
+		suite.Equal(keyOrder(key), solution.Order)
+		suite.Equal(want[:len(key)], solution.Grid[0])
+
+		dec, err := NewColumnar(solution.Key, 0).Decode(enc)
+		suite.Nil(err)
+		suite.Equal(want, dec)
+	}
+}
+
+func (suite *ColumnarTest) TestAdjacency() {
+	// the letter pairs alone find the order, whichever columns are long
+	for _, key := range []string{"GERMANY", "ZEBRA", "CIPHERTEXT"} {
+		enc, err := NewColumnar(key, 0).Encode(kerckhoffs)
+		suite.Nil(err)
+		cipher := []int{}
+		for _, c := range enc {
+			cipher = append(cipher, int(c-'A'))
+		}
+		suite.Equal(keyOrder(key), columnarAdjacency(cipher, len(key)), key)
+	}
+}
+
+func (suite *ColumnarTest) TestErrors() {
+	_, err := NewColumnar("A", 0).Encode("text")
+	suite.Equal("key must have at least 2 letters", err.Error())
+	_, err = NewColumnar("", 0).Decode("text")
+	suite.Equal("key must have at least 2 letters", err.Error())
+
+	_, err = SolveColumnar("abc", 0, SolverSettings{})
+	suite.Equal("not enough letters to solve", err.Error())
+	_, err = SolveColumnar("abcdefgh", 5, SolverSettings{})
+	suite.Equal("key length must be between 2 and half the message", err.Error())
+}
+
+func TestColumnar(t *testing.T) {
+	suite.Run(t, new(ColumnarTest))
+}
@@ -0,0 +1,306 @@
+package ciphers
+
+import (
+	"cmp"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	ngram "github.com/ubermensch/ciphers/ngram"
+	"slices"
+	"strings"
+)
+
+// How a keystream was combined with its messages.
+type KeystreamMode int
+
+const (
+	// letters A to Z added mod 26, as Vigenère adds its key
+	AdditiveKeystream KeystreamMode = iota
+	// bytes XORed with the keystream, as a one-time pad on a computer
+	XORKeystream
+)
+
+// Shortest crib whose fragments can be scored.
+const cribMinLength = 3
+
+// https://en.wikipedia.org/wiki/Running_key_cipher
+//
+// A keystream must never be used twice. Subtracting two ciphertexts
+// under the same keystream, mod 26, leaves the difference of their
+// plaintexts, and XORing them leaves their XOR, with the key gone
+// either way. A word guessed to be in one message, slid along that
+// difference, then reads off the other message wherever the guess is
+// right. A running key, a Vigenère key as long as the message taken from
+// a book, falls the same way with the key as the second message: the
+// ciphertext is the sum of two English texts.
+//
+// CribDrag keeps the plaintext found so far of both messages, so that
+// fragments can be accepted and extended a word at a time.
+type CribDrag struct {
+	mode KeystreamMode
+	// the ciphertexts combined wherever they overlap: the first minus
+	// the second mod 26, their XOR, or the lone ciphertext
+	combined []int
+	// one ciphertext, whose running key is the second message
+	runningKey bool
+	// plaintext found so far of each message, -1 where unknown
+	known [2][]int
+}
+
+// The other message where a crib is placed at Offset.
+type CribFragment struct {
+	Offset   int
+	Fragment string
+	// mean log probability of its trigrams, higher is more like English
+	Score float64
+}
+
+// Ciphertext as values: 0 to 25 for A to Z, or bytes from hex.
+func keystreamValues(s string, mode KeystreamMode) ([]int, error) {
+	values := []int{}
+	if mode == XORKeystream {
+		bytes, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+		if err != nil {
+			return nil, errors.New("expected hex: " + err.Error())
+		}
+		for _, b := range bytes {
+			values = append(values, int(b))
+		}
+		return values, nil
+	}
+
+	for _, c := range prepareInput(s) {
+		values = append(values, int(c-'A'))
+	}
+	return values, nil
+}
+
+// Plaintext as values. XOR keeps every byte, so cribs can hold spaces
+// and care about case.
+func (d *CribDrag) values(text string) []int {
+	values := []int{}
+	if d.mode == XORKeystream {
+		for _, b := range []byte(text) {
+			values = append(values, int(b))
+		}
+		return values
+	}
+
+	for _, c := range prepareInput(text) {
+		values = append(values, int(c-'A'))
+	}
+	return values
+}
+
+// Bytes outside printable ASCII show as `.`.
+func (d *CribDrag) text(values []int) string {
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case v < 0:
+			b.WriteByte('_')
+		case d.mode == AdditiveKeystream:
+			b.WriteRune(rune('A' + v))
+		case v >= ' ' && v <= '~':
+			b.WriteByte(byte(v))
+		default:
+			b.WriteByte('.')
+		}
+	}
+	return b.String()
+}
+
+// The other message at `pos` when message `m` reads `v` there.
+func (d *CribDrag) other(m int, pos int, v int) int {
+	switch {
+	case d.mode == XORKeystream:
+		return v ^ d.combined[pos]
+	case d.runningKey:
+		// the ciphertext is plaintext plus key
+		return mod(d.combined[pos]-v, 26)
+	case m == 0:
+		// the first plaintext minus the second
+		return mod(v-d.combined[pos], 26)
+	default:
+		return mod(v+d.combined[pos], 26)
+	}
+}
+
+// Bytes besides letters and digits that are common in English text.
+const cribPunctuation = " .,;:'\"!?-()\n"
+
+// Scores plaintext values as the mean log probability of their
+// trigrams, reading letters across spaces and punctuation. Bytes that
+// rarely turn up in English text, such as control characters or `{`,
+// count as unseen trigrams and break the letters either side apart, so
+// that garbage XORs sink.
+func cribScore(values []int, mode KeystreamMode) float64 {
+	trigrams := ngram.EnglishTrigrams()
+	total, grams := 0.0, 0
+	letters := []int{}
+	// scores the letters read since the last odd byte
+	flush := func() {
+		if n := len(letters) - trigrams.N() + 1; n > 0 {
+			total += trigrams.ScoreIndices(letters)
+			grams += n
+		}
+		letters = letters[:0]
+	}
+
+	for _, v := range values {
+		switch {
+		case mode == AdditiveKeystream:
+			letters = append(letters, v)
+		case v >= 'A' && v <= 'Z':
+			letters = append(letters, v-'A')
+		case v >= 'a' && v <= 'z':
+			letters = append(letters, v-'a')
+		case v >= '0' && v <= '9', v < 0x80 && strings.ContainsRune(cribPunctuation, rune(v)):
+		default:
+			flush()
+			total += trigrams.Floor()
+			grams++
+		}
+	}
+	flush()
+
+	if grams == 0 {
+		return trigrams.Floor()
+	}
+	return total / float64(grams)
+}
+
+// Works out what the other message reads where message `m` reads
+// `values` from `offset` on, into `other`. Returns the first position
+// where either message would contradict plaintext already found, or -1.
+func (d *CribDrag) contradiction(m int, offset int, values []int, other []int) int {
+	for i, v := range values {
+		other[i] = d.other(m, offset+i, v)
+		if known := d.known[m][offset+i]; known >= 0 && known != v {
+			return offset + i
+		}
+		if known := d.known[1-m][offset+i]; known >= 0 && known != other[i] {
+			return offset + i
+		}
+	}
+	return -1
+}
+
+// Slides `crib` through message 1 or 2 at every offset it fits and
+// doesn't contradict plaintext already found in either message, and
+// returns what the other message reads there, best first.
+func (d *CribDrag) Drag(crib string, message int) ([]CribFragment, error) {
+	if message != 1 && message != 2 {
+		return nil, errors.New("message must be 1 or 2")
+	}
+	m := message - 1
+	values := d.values(crib)
+	if len(values) < cribMinLength {
+		return nil, fmt.Errorf("crib must be at least %d letters", cribMinLength)
+	}
+
+	fragments := []CribFragment{}
+	other := make([]int, len(values))
+	for offset := 0; offset+len(values) <= len(d.combined); offset++ {
+		if d.contradiction(m, offset, values, other) >= 0 {
+			continue
+		}
+
+		fragments = append(fragments, CribFragment{
+			Offset:   offset,
+			Fragment: d.text(other),
+			Score:    cribScore(other, d.mode),
+		})
+	}
+
+	slices.SortStableFunc(fragments, func(a, b CribFragment) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return fragments, nil
+}
+
+// Accepts `text` as message 1 or 2 from `offset` on, which also fills in
+// the other message there. Text that contradicts what either message
+// was already found to read there is refused.
+func (d *CribDrag) Place(text string, message int, offset int) error {
+	if message != 1 && message != 2 {
+		return errors.New("message must be 1 or 2")
+	}
+	m := message - 1
+	values := d.values(text)
+	if len(values) == 0 {
+		return errors.New("nothing to place")
+	}
+	if offset < 0 || offset+len(values) > len(d.combined) {
+		return fmt.Errorf("text must fit within the %d places the ciphertexts share", len(d.combined))
+	}
+
+	other := make([]int, len(values))
+	if pos := d.contradiction(m, offset, values, other); pos >= 0 {
+		return fmt.Errorf("text contradicts what was found at %d", pos)
+	}
+	for i, v := range values {
+		d.known[m][offset+i] = v
+		d.known[1-m][offset+i] = other[i]
+	}
+	return nil
+}
+
+// Message 1 or 2 as found so far, with `_` where it is still unknown.
+// With one ciphertext, message 2 is its running key.
+func (d *CribDrag) Known(message int) string {
+	if message != 1 && message != 2 {
+		return ""
+	}
+	return d.text(d.known[message-1])
+}
+
+// How many places the ciphertexts share, which is as far as either
+// message can be recovered.
+func (d *CribDrag) Len() int {
+	return len(d.combined)
+}
+
+// `first` and `second` were written with the same keystream; with mode
+// XORKeystream they are hex. Leaving `second` empty instead attacks
+// `first` as a running key cipher, recovering its plaintext as message 1
+// and its key as message 2. Longer ciphertexts are cut to the shorter.
+func NewCribDrag(first string, second string, mode KeystreamMode) (*CribDrag, error) {
+	if mode != AdditiveKeystream && mode != XORKeystream {
+		return nil, errors.New("unknown keystream mode")
+	}
+
+	c1, err := keystreamValues(first, mode)
+	if err != nil {
+		return nil, errors.New("first ciphertext: " + err.Error())
+	}
+	c2, err := keystreamValues(second, mode)
+	if err != nil {
+		return nil, errors.New("second ciphertext: " + err.Error())
+	}
+
+	d := &CribDrag{mode: mode, runningKey: len(strings.TrimSpace(second)) == 0}
+	if d.runningKey {
+		d.combined = c1
+	} else {
+		d.combined = make([]int, min(len(c1), len(c2)))
+		for i := range d.combined {
+			if mode == XORKeystream {
+				d.combined[i] = c1[i] ^ c2[i]
+			} else {
+				d.combined[i] = mod(c1[i]-c2[i], 26)
+			}
+		}
+	}
+	if len(d.combined) < cribMinLength {
+		return nil, errors.New("not enough ciphertext to drag a crib across")
+	}
+
+	for m := range d.known {
+		d.known[m] = make([]int, len(d.combined))
+		for i := range d.known[m] {
+			d.known[m][i] = -1
+		}
+	}
+	return d, nil
+}
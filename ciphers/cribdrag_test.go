@@ -0,0 +1,170 @@
+package ciphers
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/suite"
+	ngram "github.com/ubermensch/ciphers/ngram"
+	"strings"
+	"testing"
+)
+
+type CribDragTest struct {
+	suite.Suite
+	first  string
+	second string
+	key    string
+}
+
+func (suite *CribDragTest) SetupTest() {
+	suite.first = prepareInput(kerckhoffs)
+	suite.second = prepareInput("The enemy knows the system, and so the only secret worth keeping " +
+		"is the key itself, which must never be used twice.")
+	// a page of a book as the running key
+	suite.key = prepareInput("It was the best of times, it was the worst of times, it was the age " +
+		"of wisdom, it was the age of foolishness, it was the epoch of belief.")
+}
+
+func (suite *CribDragTest) TestPadReuse() {
+	first, err := NewVigenere(suite.key).Encode(suite.first)
+	suite.Nil(err)
+	second, err := NewVigenere(suite.key).Encode(suite.second)
+	suite.Nil(err)
+
+	drag, err := NewCribDrag(first, second, AdditiveKeystream)
+	suite.Nil(err)
+	suite.Equal(len(suite.second), drag.Len())
+
+	// SYSTEM is in the first message twice early on. Dragged to the
+	// second place, at 51, it reads a fragment of ...INGISTHE... in the
+	// second message and ranks first; at the first place, at 7, the
+	// fragment ranks a close third
+	fragments, err := drag.Drag("system", 1)
+	suite.Nil(err)
+	suite.Len(fragments, drag.Len()-5)
+	suite.Equal(51, fragments[0].Offset)
+	suite.Equal(suite.second[51:57], fragments[0].Fragment)
+	suite.Equal(7, fragments[2].Offset)
+	suite.Equal(suite.second[7:13], fragments[2].Fragment)
+
+	// accepting a fragment fills in both messages, and dragging through
+	// the second message works back the other way
+	suite.Nil(drag.Place("system", 1, 7))
+	suite.Equal("_______"+suite.second[7:13], drag.Known(2)[:13])
+	fragments, err = drag.Drag("secret", 2)
+	suite.Nil(err)
+	offset := strings.Index(suite.second, "SECRET")
+	suite.Equal(offset, fragments[0].Offset)
+	suite.Equal(suite.first[offset:offset+6], fragments[0].Fragment)
+
+	// a crib that contradicts what was accepted in either message is not
+	// tried there, nor can it be placed
+	fragments, err = drag.Drag("xxxxxx", 1)
+	suite.Nil(err)
+	for _, f := range fragments {
+		suite.False(f.Offset > 1 && f.Offset < 13)
+	}
+	fragments, err = drag.Drag("xxxxxx", 2)
+	suite.Nil(err)
+	for _, f := range fragments {
+		suite.False(f.Offset > 1 && f.Offset < 13)
+	}
+	suite.Equal("text contradicts what was found at 7", drag.Place("xxxxxx", 2, 5).Error())
+	suite.Equal("_______"+suite.second[7:13], drag.Known(2)[:13])
+	// agreeing with what was found is fine
+	suite.Nil(drag.Place(suite.first[5:15], 1, 5))
+}
+
+func (suite *CribDragTest) TestRunningKey() {
+	// the key runs as far as the message, and no further
+	enc, err := NewVigenere(suite.key).Encode(suite.first[:len(suite.key)])
+	suite.Nil(err)
+
+	drag, err := NewCribDrag(enc, "", AdditiveKeystream)
+	suite.Nil(err)
+
+	// the plaintext placed reveals the key, and the key the plaintext
+	suite.Nil(drag.Place("cryptosystem", 1, 1))
+	suite.Equal("_"+suite.key[1:13], drag.Known(2)[:13])
+	worst := strings.Index(suite.key, "WORST")
+	suite.Nil(drag.Place("worst", 2, worst))
+	suite.Equal(suite.first[worst:worst+5], drag.Known(1)[worst:worst+5])
+
+	fragments, err := drag.Drag("bestoftimes", 2)
+	suite.Nil(err)
+	offset := strings.Index(suite.key, "BESTOFTIMES")
+	suite.Equal(offset, fragments[0].Offset)
+	suite.Equal(suite.first[offset:offset+11], fragments[0].Fragment)
+}
+
+func (suite *CribDragTest) TestXOR() {
+	first := "Meet me at the old mill at dawn, bring the maps."
+	second := "The shipment arrives on Tuesday by the north gate."
+	pad := []byte("qZ3$9vL!p0wE7^kR2mXc8NbT5yHfJ6uG1dS4aQ0zP9oK3iW7eV")
+	encode := func(message string) string {
+		out := make([]byte, len(message))
+		for i := range out {
+			out[i] = message[i] ^ pad[i]
+		}
+		return hex.EncodeToString(out)
+	}
+
+	drag, err := NewCribDrag(encode(first), encode(second), XORKeystream)
+	suite.Nil(err)
+	suite.Equal(len(first), drag.Len())
+
+	fragments, err := drag.Drag(" the ", 2)
+	suite.Nil(err)
+	offset := strings.Index(second, " the ")
+	suite.Equal(offset, fragments[0].Offset)
+	suite.Equal(first[offset:offset+5], fragments[0].Fragment)
+
+	suite.Nil(drag.Place("Meet me", 1, 0))
+	suite.Equal("The shi", drag.Known(2)[:7])
+	suite.Equal("Meet me_", drag.Known(1)[:8])
+}
+
+func (suite *CribDragTest) TestXORUnprintable() {
+	first := "Meet me at the old mill at dawn, bring the maps."
+	second := "T\x01H\x02E attack at dawn, and hold the bridge."
+	pad := []byte("qZ3$9vL!p0wE7^kR2mXc8NbT5yHfJ6uG1dS4aQ0zP9oK3iW7eV")
+	encode := func(message string) string {
+		out := make([]byte, len(message))
+		for i := range out {
+			out[i] = message[i] ^ pad[i]
+		}
+		return hex.EncodeToString(out)
+	}
+
+	drag, err := NewCribDrag(encode(first), encode(second), XORKeystream)
+	suite.Nil(err)
+	fragments, err := drag.Drag("Meet ", 1)
+	suite.Nil(err)
+	suite.NotEqual(0, fragments[0].Offset)
+
+	// the control bytes only show as dots, and keep T, H and E apart
+	for _, f := range fragments {
+		if f.Offset == 0 {
+			suite.Equal("T.H.E", f.Fragment)
+			suite.Equal(ngram.EnglishTrigrams().Floor(), f.Score)
+		}
+	}
+}
+
+func (suite *CribDragTest) TestErrors() {
+	_, err := NewCribDrag("AB", "", AdditiveKeystream)
+	suite.Equal("not enough ciphertext to drag a crib across", err.Error())
+	_, err = NewCribDrag("0a1b", "zz", XORKeystream)
+	suite.Equal("second ciphertext: expected hex: encoding/hex: invalid byte: U+007A 'z'", err.Error())
+
+	drag, err := NewCribDrag("ABCDEFGH", "HGFEDCBA", AdditiveKeystream)
+	suite.Nil(err)
+	_, err = drag.Drag("to", 1)
+	suite.Equal("crib must be at least 3 letters", err.Error())
+	_, err = drag.Drag("the", 3)
+	suite.Equal("message must be 1 or 2", err.Error())
+	suite.Equal("text must fit within the 8 places the ciphertexts share", drag.Place("there", 2, 4).Error())
+}
+
+func TestCribDrag(t *testing.T) {
+	suite.Run(t, new(CribDragTest))
+}
@@ -0,0 +1,384 @@
+package ciphers
+
+import (
+	"errors"
+	"fmt"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"math/rand"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// https://en.wikipedia.org/wiki/Grille_(cryptography)#Turning_grilles
+//
+// A square card with holes is laid over a grid and the message written
+// through the holes, then the card is given a quarter turn clockwise and
+// the writing continues, four times in all. The holes must expose every
+// cell of the grid exactly once over the four turns.
+type TurningGrille struct {
+	size int
+	// holes in the card's starting position, as row and column
+	holes [][2]int
+	// letters kept from the message
+	alphabet *lookup.Alphabet
+	Encoder
+	Decoder
+}
+
+// Reads a card drawn as rows of `#` for card and `O` for holes.
+func parseCard(drawing string) ([][2]int, []string, error) {
+	rows := strings.Fields(drawing)
+	holes := [][2]int{}
+
+	for r, row := range rows {
+		for c, cell := range []rune(row) {
+			switch unicode.ToUpper(cell) {
+			case 'O':
+				holes = append(holes, [2]int{r, c})
+			case '#':
+			default:
+				return nil, nil, fmt.Errorf("unexpected character in grille: %s", string(cell))
+			}
+		}
+	}
+
+	return holes, rows, nil
+}
+
+// Reads a turning grille drawn as rows of `#` for card and `O` for
+// holes, e.g. the output of String, and returns its holes and size.
+func ParseGrille(drawing string) ([][2]int, int, error) {
+	holes, rows, err := parseCard(drawing)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, row := range rows {
+		if len([]rune(row)) != len(rows) {
+			return nil, 0, errors.New("grille must be square")
+		}
+	}
+
+	return holes, len(rows), nil
+}
+
+// Reads a Cardan grille drawn as by Draw.
+func ParseCardanGrille(drawing string) ([][2]int, error) {
+	holes, _, err := parseCard(drawing)
+	return holes, err
+}
+
+// Generates a random valid grille. Cells are grouped into the orbits
+// that a quarter turn moves them around, and one cell of each orbit is
+// cut out.
+func RandomGrille(size int, seed int64) ([][2]int, error) {
+	if size < 2 {
+		return nil, errors.New("grille must be at least 2 cells across")
+	}
+	if size%2 != 0 {
+		return nil, errors.New("grille must be an even number of cells across")
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	half := size / 2
+	holes := make([][2]int, 0, half*half)
+
+	for r := 0; r < half; r++ {
+		for c := 0; c < half; c++ {
+			cell := [2]int{r, c}
+			for turns := rng.Intn(4); turns > 0; turns-- {
+				cell = rotateCell(cell, size)
+			}
+			holes = append(holes, cell)
+		}
+	}
+	sortCells(holes)
+
+	return holes, nil
+}
+
+// Where a cell ends up after the card is turned a quarter clockwise.
+func rotateCell(cell [2]int, size int) [2]int {
+	return [2]int{cell[1], size - 1 - cell[0]}
+}
+
+// Cells in reading order, row by row.
+func sortCells(cells [][2]int) {
+	slices.SortFunc(cells, func(a, b [2]int) int {
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+		return a[1] - b[1]
+	})
+}
+
+func (t *TurningGrille) validate() error {
+	if t.size < 2 || t.size%2 != 0 {
+		return errors.New("grille must be an even number of cells across")
+	}
+
+	exposed := map[[2]int]int{}
+	for _, hole := range t.holes {
+		if hole[0] < 0 || hole[0] >= t.size || hole[1] < 0 || hole[1] >= t.size {
+			return fmt.Errorf("hole outside grille: %d,%d", hole[0], hole[1])
+		}
+		cell := hole
+		for turn := 0; turn < 4; turn++ {
+			exposed[cell]++
+			cell = rotateCell(cell, t.size)
+		}
+	}
+
+	for r := 0; r < t.size; r++ {
+		for c := 0; c < t.size; c++ {
+			switch exposed[[2]int{r, c}] {
+			case 0:
+				return fmt.Errorf("cell %d,%d is never exposed", r, c)
+			case 1:
+			default:
+				return fmt.Errorf("cell %d,%d is exposed more than once", r, c)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Grid cells in the order the message is written into them.
+func (t *TurningGrille) writingOrder() [][2]int {
+	order := make([][2]int, 0, t.size*t.size)
+	holes := slices.Clone(t.holes)
+
+	for turn := 0; turn < 4; turn++ {
+		sortCells(holes)
+		order = append(order, holes...)
+		for i := range holes {
+			holes[i] = rotateCell(holes[i], t.size)
+		}
+	}
+	return order
+}
+
+// Encode pads the message with `X`, or the last letter of an alphabet
+// without one, to fill whole grids, and reads each grid off by rows.
+func (t *TurningGrille) Encode(s string) (string, error) {
+	if err := t.validate(); err != nil {
+		return "", err
+	}
+
+	cells := t.size * t.size
+	str := []rune(t.alphabet.Filter(s))
+	for len(str)%cells != 0 || len(str) == 0 {
+		str = append(str, paddingLetter(t.alphabet))
+	}
+
+	order := t.writingOrder()
+	encoded := make([]rune, len(str))
+	for block := 0; block < len(str); block += cells {
+		for i, cell := range order {
+			encoded[block+cell[0]*t.size+cell[1]] = str[block+i]
+		}
+	}
+
+	return t.groups(encoded), nil
+}
+
+func (t *TurningGrille) Decode(s string) (string, error) {
+	if err := t.validate(); err != nil {
+		return "", err
+	}
+
+	cells := t.size * t.size
+	str := []rune(t.alphabet.Filter(s))
+	if len(str)%cells != 0 {
+		return "", fmt.Errorf("ciphertext length must be a multiple of %d", cells)
+	}
+
+	order := t.writingOrder()
+	decoded := make([]rune, len(str))
+	for block := 0; block < len(str); block += cells {
+		for i, cell := range order {
+			decoded[block+i] = str[block+cell[0]*t.size+cell[1]]
+		}
+	}
+
+	return string(decoded), nil
+}
+
+// Splits the grid text into rows.
+func (t *TurningGrille) groups(runes []rune) string {
+	groups := make([]string, 0, len(runes)/t.size)
+	for i := 0; i < len(runes); i += t.size {
+		groups = append(groups, string(runes[i:i+t.size]))
+	}
+	return strings.Join(groups, " ")
+}
+
+// Draws the card with `#` for card and `O` for holes, one row per line,
+// ready to be printed and cut out.
+func (t *TurningGrille) String() string {
+	var drawing strings.Builder
+	for r := 0; r < t.size; r++ {
+		for c := 0; c < t.size; c++ {
+			if slices.Contains(t.holes, [2]int{r, c}) {
+				drawing.WriteRune('O')
+			} else {
+				drawing.WriteRune('#')
+			}
+		}
+		drawing.WriteRune('\n')
+	}
+	return drawing.String()
+}
+
+// `holes` are row and column pairs counted from the top left, in the
+// card's starting position.
+func NewTurningGrille(size int, holes [][2]int, opts ...Option) *TurningGrille {
+	return &TurningGrille{
+		size:     size,
+		holes:    holes,
+		alphabet: newOptions(opts).alphabet,
+	}
+}
+
+// https://en.wikipedia.org/wiki/Cardan_grille
+//
+// The message letters are hidden among the letters of an innocent cover
+// text, and a card with holes over just those letters reveals them.
+type CardanGrille struct {
+	// characters per line when laying the cover text out as a page
+	width int
+}
+
+// Finds the message letters in order within the cover text, spread as
+// evenly as the cover allows, and returns the cover text laid out in
+// lines of the grille's width together with the positions of the holes.
+func (g *CardanGrille) Hide(s string, cover string) (string, [][2]int, error) {
+	if g.width < 1 {
+		return "", nil, errors.New("expected positive line width")
+	}
+
+	page := g.layout(cover)
+	cells := [][2]int{}
+	for r, line := range page {
+		for c := range line {
+			cells = append(cells, [2]int{r, c})
+		}
+	}
+	letterAt := func(j int) rune {
+		return unicode.ToUpper(page[cells[j][0]][cells[j][1]])
+	}
+
+	// latest cell each letter can take while leaving room for the rest
+	message := []rune(prepareInput(s))
+	latest := make([]int, len(message))
+	j := len(cells) - 1
+	for i := len(message) - 1; i >= 0; i-- {
+		for j >= 0 && letterAt(j) != message[i] {
+			j--
+		}
+		if j < 0 {
+			return "", nil, errors.New("cover text doesn't contain the message letters in order")
+		}
+		latest[i] = j
+		j--
+	}
+
+	// aim each letter at its evenly spaced share of the cover
+	holes := make([][2]int, 0, len(message))
+	next := 0
+	for i, m := range message {
+		target := max(next, i*len(cells)/len(message))
+		chosen := -1
+		for k := next; k <= latest[i]; k++ {
+			if letterAt(k) != m {
+				continue
+			}
+			chosen = k
+			if k >= target {
+				break
+			}
+		}
+		holes = append(holes, cells[chosen])
+		next = chosen + 1
+	}
+
+	lines := make([]string, len(page))
+	for i, line := range page {
+		lines[i] = string(line)
+	}
+	return strings.Join(lines, "\n"), holes, nil
+}
+
+// Reads the letters under the holes, in reading order.
+func (g *CardanGrille) Extract(text string, holes [][2]int) (string, error) {
+	page := strings.Split(text, "\n")
+	sorted := slices.Clone(holes)
+	sortCells(sorted)
+
+	extracted := make([]rune, 0, len(sorted))
+	for _, hole := range sorted {
+		if hole[0] < 0 || hole[0] >= len(page) {
+			return "", fmt.Errorf("hole outside text: %d,%d", hole[0], hole[1])
+		}
+		line := []rune(page[hole[0]])
+		if hole[1] < 0 || hole[1] >= len(line) {
+			return "", fmt.Errorf("hole outside text: %d,%d", hole[0], hole[1])
+		}
+		extracted = append(extracted, unicode.ToUpper(line[hole[1]]))
+	}
+
+	return string(extracted), nil
+}
+
+// Draws the card for a page of `lines` lines, with `#` for card and `O`
+// for holes.
+func (g *CardanGrille) Draw(holes [][2]int, lines int) string {
+	var drawing strings.Builder
+	for r := 0; r < lines; r++ {
+		for c := 0; c < g.width; c++ {
+			if slices.Contains(holes, [2]int{r, c}) {
+				drawing.WriteRune('O')
+			} else {
+				drawing.WriteRune('#')
+			}
+		}
+		drawing.WriteRune('\n')
+	}
+	return drawing.String()
+}
+
+// Wraps the cover text into lines of at most `width` characters,
+// breaking between words where possible.
+func (g *CardanGrille) layout(cover string) [][]rune {
+	page := [][]rune{}
+	line := []rune{}
+
+	for _, word := range strings.Fields(cover) {
+		w := []rune(word)
+		if len(line) > 0 && len(line)+1+len(w) > g.width {
+			page = append(page, line)
+			line = []rune{}
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		for len(w) > g.width {
+			page = append(page, append(line, w[:g.width-len(line)]...))
+			w = w[g.width-len(line):]
+			line = []rune{}
+		}
+		line = append(line, w...)
+	}
+	if len(line) > 0 {
+		page = append(page, line)
+	}
+
+	return page
+}
+
+func NewCardanGrille(width int) *CardanGrille {
+	return &CardanGrille{
+		width: width,
+	}
+}
@@ -0,0 +1,112 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type GrilleTest struct {
+	suite.Suite
+	holes [][2]int
+}
+
+func (suite *GrilleTest) SetupTest() {
+	suite.holes = [][2]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+}
+
+func (suite *GrilleTest) TestTurningGrille() {
+	tg := NewTurningGrille(4, suite.holes)
+
+	enc, err := tg.Encode("abcdefghijklmnop")
+	suite.Nil(err)
+	suite.Equal("ABEF CDGH MNIJ OPKL", enc)
+
+	dec, err := tg.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("ABCDEFGHIJKLMNOP", dec)
+
+	// short messages are padded to fill the grid
+	enc, err = tg.Encode("abc")
+	suite.Nil(err)
+	suite.Equal("ABXX CXXX XXXX XXXX", enc)
+}
+
+func (suite *GrilleTest) TestDrawing() {
+	tg := NewTurningGrille(4, suite.holes)
+	suite.Equal("OO##\nOO##\n####\n####\n", tg.String())
+
+	holes, size, err := ParseGrille(tg.String())
+	suite.Nil(err)
+	suite.Equal(4, size)
+	suite.Equal(suite.holes, holes)
+}
+
+func (suite *GrilleTest) TestRandomGrille() {
+	for seed := int64(0); seed < 10; seed++ {
+		holes, err := RandomGrille(6, seed)
+		suite.Nil(err)
+		suite.Len(holes, 9)
+
+		tg := NewTurningGrille(6, holes)
+		suite.Nil(tg.validate())
+
+		enc, err := tg.Encode("the quick brown fox jumps over the lazy dog")
+		suite.Nil(err)
+		dec, err := tg.Decode(enc)
+		suite.Nil(err)
+		suite.Equal("THEQUICKBROWNFOXJUMPSOVERTHELAZYDOGX", dec)
+	}
+}
+
+func (suite *GrilleTest) TestCardanGrille() {
+	cg := NewCardanGrille(16)
+
+	page, holes, err := cg.Hide("tox", "The quick brown fox jumps over the lazy dog")
+	suite.Nil(err)
+	suite.Equal("The quick brown\nfox jumps over\nthe lazy dog", page)
+	suite.Equal([][2]int{{0, 0}, {1, 1}, {1, 2}}, holes)
+
+	extracted, err := cg.Extract(page, holes)
+	suite.Nil(err)
+	suite.Equal("TOX", extracted)
+
+	suite.Equal(
+		"O###############\n"+
+			"#OO#############\n"+
+			"################\n",
+		cg.Draw(holes, 3),
+	)
+
+	parsed, err := ParseCardanGrille(cg.Draw(holes, 3))
+	suite.Nil(err)
+	suite.Equal(holes, parsed)
+}
+
+func (suite *GrilleTest) TestErrors() {
+	tg := NewTurningGrille(4, [][2]int{{0, 0}, {0, 3}, {1, 0}, {1, 1}})
+	_, err := tg.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("cell 0,0 is exposed more than once", err.Error())
+
+	tg = NewTurningGrille(4, [][2]int{{0, 0}, {1, 1}})
+	_, err = tg.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("cell 0,1 is never exposed", err.Error())
+
+	_, _, err = ParseGrille("O#\n#")
+	suite.NotNil(err)
+	suite.Equal("grille must be square", err.Error())
+
+	_, err = RandomGrille(5, 0)
+	suite.NotNil(err)
+	suite.Equal("grille must be an even number of cells across", err.Error())
+
+	cg := NewCardanGrille(10)
+	_, _, err = cg.Hide("zebra", "The quick brown fox")
+	suite.NotNil(err)
+	suite.Equal("cover text doesn't contain the message letters in order", err.Error())
+}
+
+func TestGrille(t *testing.T) {
+	suite.Run(t, new(GrilleTest))
+}
@@ -0,0 +1,148 @@
+package ciphers
+
+import (
+	"errors"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"slices"
+	"unicode"
+)
+
+// https://www.cryptogram.org/resource-area/cipher-types/
+//
+// GROnsfeld with Mixed Alphabet and Running Key. A short numeric primer
+// is extended into a running key by adding neighbouring digits, e.g.
+// `23452` continues `5797...`. Each letter is moved along the straight
+// alphabet by its key digit and replaced with the letter in the same
+// place in a mixed alphabet.
+type Gromark struct {
+	key    string
+	primer string
+	// use a cipher alphabet starting at each key letter in turn, and
+	// take the primer from the key
+	periodic bool
+	// the straight alphabet, which the mixed one is keyed from
+	alphabet *lookup.Alphabet
+	Encoder
+	Decoder
+}
+
+// Writes the keyed alphabet in rows under the distinct key letters and
+// reads it off by columns in the key's alphabetical order, e.g. ENIGMA
+// gives `AJRXEBKSYGFPVIDOUMHQWNCLTZ`.
+func gromarkAlphabet(key string, alphabet *lookup.Alphabet) []rune {
+	distinct := []rune{}
+	for _, c := range alphabet.Filter(key) {
+		if !slices.Contains(distinct, c) {
+			distinct = append(distinct, c)
+		}
+	}
+
+	keyed := lookup.NewKeyedAlphabet(key, alphabet)
+	width := len(distinct)
+	columns := make([][]rune, width)
+	for i := 0; i < keyed.Len(); i++ {
+		columns[i%width] = append(columns[i%width], keyed.At(i))
+	}
+
+	order := alphabetKeyOrder(string(distinct), alphabet)
+	mixed := make([][]rune, width)
+	for col, rank := range order {
+		mixed[rank] = columns[col]
+	}
+	return slices.Concat(mixed...)
+}
+
+// The primer followed by as many running key digits as `length` needs.
+func (g *Gromark) runningKey(primer []int, length int) []int {
+	digits := slices.Clone(primer)
+	for i := len(primer); i < length; i++ {
+		digits = append(digits, (digits[i-len(primer)]+digits[i-len(primer)+1])%10)
+	}
+	return digits
+}
+
+// Checks the key and primer, and returns the key letters, primer digits
+// and mixed alphabet.
+func (g *Gromark) prepare() ([]rune, []int, []rune, error) {
+	key := []rune(g.alphabet.Filter(g.key))
+	if len(key) == 0 {
+		return nil, nil, nil, errors.New("empty key")
+	}
+
+	primer := []int{}
+	if g.periodic {
+		for _, rank := range alphabetKeyOrder(string(key), g.alphabet) {
+			primer = append(primer, (rank+1)%10)
+		}
+	} else {
+		for _, c := range g.primer {
+			if !unicode.IsDigit(c) {
+				return nil, nil, nil, errors.New("primer must only contain digits")
+			}
+			primer = append(primer, int(c-'0'))
+		}
+	}
+	if len(primer) < 2 {
+		return nil, nil, nil, errors.New("primer must be at least two digits")
+	}
+
+	return key, primer, gromarkAlphabet(string(key), g.alphabet), nil
+}
+
+func (g *Gromark) shift(s string, direction int) (string, error) {
+	key, primer, mixed, err := g.prepare()
+	if err != nil {
+		return "", err
+	}
+
+	size := g.alphabet.Len()
+	str := []rune(g.alphabet.Filter(s))
+	digits := g.runningKey(primer, len(str))
+	shifted := make([]rune, len(str))
+
+	for i, c := range str {
+		// periodic alphabets start at each key letter for a key length
+		// of letters at a time
+		start := 0
+		if g.periodic {
+			start = slices.Index(mixed, key[i/len(key)%len(key)])
+		}
+
+		if direction > 0 {
+			shifted[i] = mixed[(start+g.alphabet.Index(c)+digits[i])%size]
+			continue
+		}
+		shifted[i] = g.alphabet.At(slices.Index(mixed, c) - start - digits[i])
+	}
+
+	return string(shifted), nil
+}
+
+func (g *Gromark) Encode(s string) (string, error) {
+	return g.shift(s, 1)
+}
+
+func (g *Gromark) Decode(s string) (string, error) {
+	return g.shift(s, -1)
+}
+
+// `primer` is a string of digits, usually five, that starts the
+// running key.
+func NewGromark(key string, primer string, opts ...Option) *Gromark {
+	return &Gromark{
+		key:      key,
+		primer:   primer,
+		alphabet: newOptions(opts).alphabet,
+	}
+}
+
+// The primer is the alphabetical order of the key letters, e.g. ENIGMA
+// gives `264351`, and the cipher alphabet changes after each key length
+// of letters.
+func NewPeriodicGromark(key string, opts ...Option) *Gromark {
+	return &Gromark{
+		key:      key,
+		periodic: true,
+		alphabet: newOptions(opts).alphabet,
+	}
+}
@@ -0,0 +1,74 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"testing"
+)
+
+type gromarkCase struct {
+	gromark *Gromark
+	plain   string
+	encoded string
+}
+
+type GromarkTest struct {
+	suite.Suite
+	cases []*gromarkCase
+}
+
+func (suite *GromarkTest) SetupTest() {
+	suite.cases = []*gromarkCase{
+		// the ACA's example
+		{
+			gromark: NewGromark("ENIGMA", "23452"),
+			plain:   "THEREAREUPTOTENSUBSTITUTESPERLETTER",
+			encoded: "NFYCKBTIJCNWZYCACJNAYNLQPWWSTWPJQFL",
+		},
+		{
+			gromark: NewPeriodicGromark("ENIGMA"),
+			plain:   "WINTERISICUMENINLHUDESINGGODDAMM",
+			encoded: "RHNAICPIFBNUZSAEXJYDCFQTETINTAWN",
+		},
+	}
+}
+
+func (suite *GromarkTest) TestAlphabet() {
+	suite.Equal("AJRXEBKSYGFPVIDOUMHQWNCLTZ", string(gromarkAlphabet("ENIGMA", lookup.Latin)))
+	// repeated key letters only head one column
+	suite.Equal("EBFIMQTWZKADHLOSVYPCGJNRUX", string(gromarkAlphabet("KEEP", lookup.Latin)))
+}
+
+func (suite *GromarkTest) TestEncoding() {
+	for _, cs := range suite.cases {
+		enc, err := cs.gromark.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *GromarkTest) TestDecoding() {
+	for _, cs := range suite.cases {
+		dec, err := cs.gromark.Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.plain, dec)
+	}
+}
+
+func (suite *GromarkTest) TestErrors() {
+	_, err := NewGromark("", "23452").Encode("text")
+	suite.EqualError(err, "empty key")
+
+	_, err = NewGromark("ENIGMA", "23a52").Encode("text")
+	suite.EqualError(err, "primer must only contain digits")
+
+	_, err = NewGromark("ENIGMA", "2").Encode("text")
+	suite.EqualError(err, "primer must be at least two digits")
+
+	_, err = NewPeriodicGromark("E").Encode("text")
+	suite.EqualError(err, "primer must be at least two digits")
+}
+
+func TestGromark(t *testing.T) {
+	suite.Run(t, new(GromarkTest))
+}
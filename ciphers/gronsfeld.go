@@ -0,0 +1,69 @@
+package ciphers
+
+import (
+	"errors"
+)
+
+// https://en.wikipedia.org/wiki/Vigen%C3%A8re_cipher#Gronsfeld_cipher
+type Gronsfeld struct {
+	// numeric key, e.g. `31415`
+	key string
+	polyalphabetic
+	Encoder
+	Decoder
+}
+
+// Like Vigenère, but each key digit is the shift itself.
+func (g *Gronsfeld) keyOffset(pos int) int {
+	key := []rune(g.key)
+	return int(key[pos%len(key)] - '0')
+}
+
+func (g *Gronsfeld) validate() error {
+	if len(g.key) == 0 {
+		return errors.New("empty key")
+	}
+	for _, d := range g.key {
+		if d < '0' || d > '9' {
+			return errors.New("key must only contain digits")
+		}
+	}
+	return nil
+}
+
+func (g *Gronsfeld) Encode(s string) (string, error) {
+	if err := g.validate(); err != nil {
+		return "", err
+	}
+
+	encoded, err := g.transform(s, func(c rune, pos int) (rune, error) {
+		return g.shiftChar(c, g.keyOffset(pos))
+	})
+	if err != nil {
+		return "", errors.New("encoding failed")
+	}
+
+	return encoded, nil
+}
+
+func (g *Gronsfeld) Decode(s string) (string, error) {
+	if err := g.validate(); err != nil {
+		return "", err
+	}
+
+	decoded, err := g.transform(s, func(c rune, pos int) (rune, error) {
+		return g.shiftChar(c, -g.keyOffset(pos))
+	})
+	if err != nil {
+		return "", errors.New("decoding failed")
+	}
+
+	return decoded, nil
+}
+
+func NewGronsfeld(key string, opts ...Option) *Gronsfeld {
+	return &Gronsfeld{
+		key:            key,
+		polyalphabetic: newPolyalphabetic(newOptions(opts).alphabet),
+	}
+}
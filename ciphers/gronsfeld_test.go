@@ -0,0 +1,73 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type gronsfeldCase struct {
+	key     string
+	plain   string
+	encoded string
+}
+
+type GronsfeldTest struct {
+	suite.Suite
+	cases []*gronsfeldCase
+}
+
+func (suite *GronsfeldTest) SetupTest() {
+	suite.cases = []*gronsfeldCase{
+		{
+			key:     "31415",
+			plain:   "attackatdawn",
+			encoded: "duxbhnbxefzo",
+		},
+		{
+			key:     "0",
+			plain:   "Nothing changes.",
+			encoded: "Nothing changes.",
+		},
+		{
+			key:     "12",
+			plain:   "Yes, Zed!",
+			encoded: "Zgt, Bff!",
+		},
+	}
+}
+
+func (suite *GronsfeldTest) TestEncoding() {
+	for _, cs := range suite.cases {
+		gf := NewGronsfeld(cs.key)
+
+		enc, err := gf.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *GronsfeldTest) TestDecoding() {
+	for _, cs := range suite.cases {
+		gf := NewGronsfeld(cs.key)
+
+		dec, err := gf.Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.plain, dec)
+	}
+}
+
+func (suite *GronsfeldTest) TestErrors() {
+	gf := NewGronsfeld("")
+	_, err := gf.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("empty key", err.Error())
+
+	gf = NewGronsfeld("31a15")
+	_, err = gf.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("key must only contain digits", err.Error())
+}
+
+func TestGronsfeld(t *testing.T) {
+	suite.Run(t, new(GronsfeldTest))
+}
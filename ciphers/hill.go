@@ -0,0 +1,230 @@
+package ciphers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// https://en.wikipedia.org/wiki/Hill_cipher
+//
+// Each block of n letters, read as a vector of 0 to 25, is multiplied by
+// an n x n key matrix mod 26. The key is written as its n² letters row by
+// row, e.g. `GYBNQKURP` for a 3 x 3 matrix. Being linear, it falls to a
+// known-plaintext attack: see SolveHill.
+type Hill struct {
+	key [][]int
+	Encoder
+	Decoder
+}
+
+// Most crib blocks SolveHill chooses among for an invertible set, so
+// that long cribs don't take forever. The rest still check the key.
+const hillMaxChoiceBlocks = 24
+
+// A key recovered by SolveHill.
+type HillSolution struct {
+	Matrix [][]int
+	// the matrix as letters row by row, ready for NewHill
+	Key       string
+	Plaintext string
+}
+
+// The key matrix from its letters, row by row.
+func hillMatrix(key string) ([][]int, error) {
+	letters := []rune(prepareInput(key))
+	size := int(math.Round(math.Sqrt(float64(len(letters)))))
+	if size < 2 || size*size != len(letters) {
+		return nil, errors.New("key must be a square number of letters, at least 4")
+	}
+
+	matrix := make([][]int, size)
+	for r := range matrix {
+		matrix[r] = make([]int, size)
+		for c := range matrix[r] {
+			matrix[r][c] = int(letters[r*size+c] - 'A')
+		}
+	}
+	return matrix, nil
+}
+
+func hillKey(matrix [][]int) string {
+	key := []rune{}
+	for _, row := range matrix {
+		for _, v := range row {
+			key = append(key, rune('A'+v))
+		}
+	}
+	return string(key)
+}
+
+// Multiplies each block of `letters` by `matrix`.
+func hillTransform(letters []rune, matrix [][]int) string {
+	size := len(matrix)
+	out := make([]rune, 0, len(letters))
+	block := make([][]int, size)
+	for i := 0; i < len(letters); i += size {
+		for r := range block {
+			block[r] = []int{int(letters[i+r] - 'A')}
+		}
+		for _, v := range multiplyMatrices(matrix, block, 26) {
+			out = append(out, rune('A'+v[0]))
+		}
+	}
+	return string(out)
+}
+
+// Encode pads the last block with `X`.
+func (h *Hill) Encode(s string) (string, error) {
+	if h.key == nil {
+		return "", errors.New("key must be a square number of letters, at least 4")
+	}
+	if _, err := invertMatrix(h.key, 26); err != nil {
+		return "", errors.New("key matrix is not invertible mod 26, so messages could not be decoded")
+	}
+
+	plain := []rune(prepareInput(s))
+	for len(plain)%len(h.key) != 0 {
+		plain = append(plain, 'X')
+	}
+	return hillTransform(plain, h.key), nil
+}
+
+// Decode leaves any padding in place.
+func (h *Hill) Decode(s string) (string, error) {
+	if h.key == nil {
+		return "", errors.New("key must be a square number of letters, at least 4")
+	}
+	inverse, err := invertMatrix(h.key, 26)
+	if err != nil {
+		return "", errors.New("key matrix is not invertible mod 26")
+	}
+
+	cipher := []rune(prepareInput(s))
+	if len(cipher)%len(h.key) != 0 {
+		return "", fmt.Errorf("ciphertext length must be a multiple of %d", len(h.key))
+	}
+	return hillTransform(cipher, inverse), nil
+}
+
+// Calls `try` with each choice of `k` of the numbers 0 to n-1, in
+// increasing order, until it returns true.
+func combinations(n int, k int, try func([]int) bool) bool {
+	chosen := make([]int, k)
+	var pick func(i int, from int) bool
+	pick = func(i int, from int) bool {
+		if i == k {
+			return try(chosen)
+		}
+		for c := from; c <= n-(k-i); c++ {
+			chosen[i] = c
+			if pick(i+1, c+1) {
+				return true
+			}
+		}
+		return false
+	}
+	return pick(0, 0)
+}
+
+// Recovers the key matrix of a Hill cipher with blocks of `size`
+// letters from a crib: plaintext known to start `offset` letters into
+// the message. With the plaintext blocks of the crib as the columns of
+// P and the matching ciphertext blocks as those of C, the key K has
+// K·P = C, so K = C·P⁻¹ mod 26. That needs `size` crib blocks whose
+// matrix is invertible mod 26, so every choice of blocks is tried until
+// one is. The key found must then turn every crib block into its
+// ciphertext, or the crib is wrong.
+func SolveHill(s string, crib string, offset int, size int) (*HillSolution, error) {
+	if size < 2 {
+		return nil, errors.New("block size must be at least 2")
+	}
+	if offset < 0 {
+		return nil, errors.New("crib offset must not be negative")
+	}
+
+	cipher := []rune(prepareInput(s))
+	plain := []rune(prepareInput(crib))
+
+	// only blocks wholly inside the crib are any use
+	skip := (size - offset%size) % size
+	start := offset + skip
+	blocks := 0
+	if skip < len(plain) && start < len(cipher) {
+		blocks = min(len(plain)-skip, len(cipher)-start) / size
+	}
+	if blocks < size {
+		return nil, fmt.Errorf(
+			"crib is insufficient: it covers %d whole blocks of the ciphertext and %d are needed",
+			blocks, size,
+		)
+	}
+
+	// column vectors of each crib block and its ciphertext
+	column := func(letters []rune, block int) []int {
+		v := make([]int, size)
+		for i := range v {
+			v[i] = int(letters[block*size+i] - 'A')
+		}
+		return v
+	}
+	plainBlocks := make([][]int, blocks)
+	cipherBlocks := make([][]int, blocks)
+	for b := 0; b < blocks; b++ {
+		plainBlocks[b] = column(plain[skip:], b)
+		cipherBlocks[b] = column(cipher[start:], b)
+	}
+	asColumns := func(vectors [][]int, chosen []int) [][]int {
+		m := make([][]int, size)
+		for r := range m {
+			m[r] = make([]int, size)
+			for c, b := range chosen {
+				m[r][c] = vectors[b][r]
+			}
+		}
+		return m
+	}
+
+	var key [][]int
+	combinations(min(blocks, hillMaxChoiceBlocks), size, func(chosen []int) bool {
+		inverse, err := invertMatrix(asColumns(plainBlocks, chosen), 26)
+		if err != nil {
+			return false
+		}
+		key = multiplyMatrices(asColumns(cipherBlocks, chosen), inverse, 26)
+		return true
+	})
+	if key == nil {
+		return nil, fmt.Errorf(
+			"crib is insufficient: no %d of its %d blocks are independent mod 26",
+			size, min(blocks, hillMaxChoiceBlocks),
+		)
+	}
+
+	for b := range plainBlocks {
+		vector := make([][]int, size)
+		for r := range vector {
+			vector[r] = []int{plainBlocks[b][r]}
+		}
+		for r, v := range multiplyMatrices(key, vector, 26) {
+			if v[0] != cipherBlocks[b][r] {
+				return nil, fmt.Errorf("crib does not fit any Hill key with blocks of %d at offset %d", size, offset)
+			}
+		}
+	}
+
+	solution := &HillSolution{Matrix: key, Key: hillKey(key)}
+	plaintext, err := NewHill(solution.Key).Decode(s)
+	if err != nil {
+		return nil, errors.New("recovered key cannot decode the message: " + err.Error())
+	}
+	solution.Plaintext = plaintext
+	return solution, nil
+}
+
+// `key` gives the matrix's letters row by row.
+func NewHill(key string) *Hill {
+	// a bad key is reported when encoding or decoding
+	matrix, _ := hillMatrix(key)
+	return &Hill{key: matrix}
+}
@@ -0,0 +1,115 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type hillCase struct {
+	key     string
+	plain   string
+	encoded string
+}
+
+type HillTest struct {
+	suite.Suite
+	cases []*hillCase
+}
+
+func (suite *HillTest) SetupTest() {
+	// https://en.wikipedia.org/wiki/Hill_cipher
+	suite.cases = []*hillCase{
+		{key: "GYBNQKURP", plain: "ACT", encoded: "POH"},
+		{key: "GYBNQKURP", plain: "CAT", encoded: "FIN"},
+		{key: "DDCF", plain: "HELP", encoded: "HIAT"},
+	}
+}
+
+func (suite *HillTest) TestEncode() {
+	for _, cs := range suite.cases {
+		enc, err := NewHill(cs.key).Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+
+	// padded to whole blocks
+	enc, err := NewHill("GYBNQKURP").Encode("acts")
+	suite.Nil(err)
+	suite.Len(enc, 6)
+}
+
+func (suite *HillTest) TestDecode() {
+	for _, cs := range suite.cases {
+		dec, err := NewHill(cs.key).Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.plain, dec)
+	}
+}
+
+func (suite *HillTest) TestMatrix() {
+	inverse, ok := modInverse(3, 26)
+	suite.True(ok)
+	suite.Equal(9, inverse)
+	_, ok = modInverse(13, 26)
+	suite.False(ok)
+
+	key := [][]int{{6, 24, 1}, {13, 16, 10}, {20, 17, 15}}
+	suite.Equal(25, determinant(key, 26))
+	inv, err := invertMatrix(key, 26)
+	suite.Nil(err)
+	suite.Equal([][]int{{8, 5, 10}, {21, 8, 21}, {21, 12, 8}}, inv)
+	suite.Equal([][]int{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}, multiplyMatrices(key, inv, 26))
+
+	_, err = invertMatrix([][]int{{2, 0}, {0, 1}}, 26)
+	suite.Equal("matrix is not invertible", err.Error())
+}
+
+func (suite *HillTest) TestSolve() {
+	enc, err := NewHill("GYBNQKURP").Encode(kerckhoffs)
+	suite.Nil(err)
+
+	solution, err := SolveHill(enc, "acryptosystemshouldbesecureevenif", 0, 3)
+	suite.Nil(err)
+	suite.Equal("GYBNQKURP", solution.Key)
+	suite.Equal([][]int{{6, 24, 1}, {13, 16, 10}, {20, 17, 15}}, solution.Matrix)
+	suite.Equal(prepareInput(kerckhoffs), solution.Plaintext)
+
+	// a crib from the middle is cut to whole blocks
+	solution, err = SolveHill(enc, "feverythingabout", 32, 3)
+	suite.Nil(err)
+	suite.Equal("GYBNQKURP", solution.Key)
+
+	// no two of AA, AA, CC and EL are independent mod 26, so the search
+	// goes on until it pairs TH with EL
+	enc, err = NewHill("DDCF").Encode("aaaaccthelp")
+	suite.Nil(err)
+	solution, err = SolveHill(enc, "aaaaccthelp", 0, 2)
+	suite.Nil(err)
+	suite.Equal("DDCF", solution.Key)
+}
+
+func (suite *HillTest) TestErrors() {
+	_, err := NewHill("ABC").Encode("text")
+	suite.Equal("key must be a square number of letters, at least 4", err.Error())
+	_, err = NewHill("AAAA").Decode("text")
+	suite.Equal("key matrix is not invertible mod 26", err.Error())
+	_, err = NewHill("DDCF").Decode("abc")
+	suite.Equal("ciphertext length must be a multiple of 2", err.Error())
+
+	enc, err := NewHill("GYBNQKURP").Encode(kerckhoffs)
+	suite.Nil(err)
+	_, err = SolveHill(enc, "acrypto", 0, 3)
+	suite.Equal("crib is insufficient: it covers 2 whole blocks of the ciphertext and 3 are needed", err.Error())
+	_, err = SolveHill("ABCDEFGHI", "aaaaaaaaa", 0, 3)
+	suite.Equal("crib is insufficient: no 3 of its 3 blocks are independent mod 26", err.Error())
+	// ACR YPT OSY STE all start with an even letter, so no three of them
+	// are independent mod 2
+	_, err = SolveHill(enc, "acryptosystem", 0, 3)
+	suite.Equal("crib is insufficient: no 3 of its 4 blocks are independent mod 26", err.Error())
+	_, err = SolveHill(enc, "thequickbrownfox", 0, 3)
+	suite.Equal("crib does not fit any Hill key with blocks of 3 at offset 0", err.Error())
+}
+
+func TestHill(t *testing.T) {
+	suite.Run(t, new(HillTest))
+}
@@ -0,0 +1,182 @@
+package ciphers
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"io"
+	"math/rand"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// https://en.wikipedia.org/wiki/Substitution_cipher#Homophonic
+//
+// Each letter has several cipher symbols, its homophones, so common
+// letters can be spread over many symbols to flatten the frequencies.
+type Homophonic struct {
+	table map[rune][]string
+	// symbol to letter
+	reverse map[string]rune
+	choice  HomophoneChoice
+	rng     *rand.Rand
+	// next homophone for each letter when taking them in turn
+	next map[rune]int
+	Encoder
+	Decoder
+}
+
+// How a homophone is picked for each letter.
+type HomophoneChoice int
+
+const (
+	RandomChoice HomophoneChoice = iota
+	RoundRobinChoice
+)
+
+// Reads a homophone table from JSON, mapping each letter to a list of
+// symbols, e.g. `{"A": ["14", "31", "72"], "B": ["05"]}`.
+func ParseHomophoneTable(r io.Reader) (map[rune][]string, error) {
+	raw := map[string][]string{}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, errors.New("could not parse homophone table: " + err.Error())
+	}
+
+	table := map[rune][]string{}
+	for letter, symbols := range raw {
+		if utf8.RuneCountInString(letter) != 1 {
+			return nil, fmt.Errorf("expected a single letter: %s", letter)
+		}
+		c, _ := utf8.DecodeRuneInString(letter)
+		table[unicode.ToUpper(c)] = symbols
+	}
+
+	return table, nil
+}
+
+// Generates a table of `symbols` two or three digit homophones, shared
+// out in proportion to English letter frequencies with at least one per
+// letter, and shuffled.
+func GenerateHomophoneTable(symbols int, seed int64) (map[rune][]string, error) {
+	if symbols < 26 {
+		return nil, errors.New("need at least 26 symbols")
+	}
+
+	letters := make([]rune, 0, 26)
+	for c := range lookup.EnglishFrequencies {
+		letters = append(letters, c)
+	}
+	slices.Sort(letters)
+
+	// one symbol each, then the rest by largest remainder
+	counts := map[rune]int{}
+	remainders := map[rune]float64{}
+	left := symbols - 26
+	for _, c := range letters {
+		share := lookup.EnglishFrequencies[c] / 100 * float64(symbols-26)
+		counts[c] = 1 + int(share)
+		remainders[c] = share - float64(int(share))
+		left -= int(share)
+	}
+	byRemainder := slices.Clone(letters)
+	slices.SortStableFunc(byRemainder, func(a, b rune) int {
+		return cmp.Compare(remainders[b], remainders[a])
+	})
+	for i := 0; i < left; i++ {
+		counts[byRemainder[i%26]]++
+	}
+
+	width := max(len(fmt.Sprint(symbols-1)), 2)
+	rng := rand.New(rand.NewSource(seed))
+	numbers := rng.Perm(symbols)
+
+	table := map[rune][]string{}
+	i := 0
+	for _, c := range letters {
+		for n := 0; n < counts[c]; n++ {
+			table[c] = append(table[c], fmt.Sprintf("%0*d", width, numbers[i]))
+			i++
+		}
+	}
+
+	return table, nil
+}
+
+func (h *Homophonic) homophone(c rune) (string, error) {
+	symbols, ok := h.table[c]
+	if !ok || len(symbols) == 0 {
+		return "", fmt.Errorf("no homophones for: %s", string(c))
+	}
+
+	switch h.choice {
+	case RoundRobinChoice:
+		symbol := symbols[h.next[c]%len(symbols)]
+		h.next[c]++
+		return symbol, nil
+	default:
+		return symbols[h.rng.Intn(len(symbols))], nil
+	}
+}
+
+// Encode returns the symbols separated by spaces. Letters are taken in
+// order, so random choices are repeatable for a given seed.
+func (h *Homophonic) Encode(s string) (string, error) {
+	str := prepareInput(s)
+	symbols := make([]string, 0, len(str))
+
+	for _, c := range str {
+		symbol, err := h.homophone(c)
+		if err != nil {
+			return "", err
+		}
+		symbols = append(symbols, symbol)
+	}
+
+	return strings.Join(symbols, " "), nil
+}
+
+// Decode expects whitespace separated symbols, as produced by Encode.
+func (h *Homophonic) Decode(s string) (string, error) {
+	groups := strings.Fields(s)
+	runes := make([]rune, len(groups))
+
+	for i, g := range groups {
+		c, ok := h.reverse[g]
+		if !ok {
+			return "", fmt.Errorf("unknown symbol at position %d: %s", i, g)
+		}
+		runes[i] = c
+	}
+
+	return string(runes), nil
+}
+
+// Random choices are made from a generator seeded with `seed`.
+func NewHomophonic(table map[rune][]string, choice HomophoneChoice, seed int64) (*Homophonic, error) {
+	reverse := map[string]rune{}
+	for c, symbols := range table {
+		for _, symbol := range symbols {
+			if strings.ContainsFunc(symbol, unicode.IsSpace) || len(symbol) == 0 {
+				return nil, fmt.Errorf("symbol for %s must be non-empty without spaces", string(c))
+			}
+			if other, ok := reverse[symbol]; ok && other != c {
+				return nil, fmt.Errorf(
+					"symbol %s used for both %s and %s", symbol, string(other), string(c),
+				)
+			}
+			reverse[symbol] = c
+		}
+	}
+
+	return &Homophonic{
+		table:   table,
+		reverse: reverse,
+		choice:  choice,
+		rng:     rand.New(rand.NewSource(seed)),
+		next:    map[rune]int{},
+	}, nil
+}
@@ -0,0 +1,118 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type HomophonicTest struct {
+	suite.Suite
+	table map[rune][]string
+}
+
+func (suite *HomophonicTest) SetupTest() {
+	suite.table = map[rune][]string{
+		'A': {"10", "11"},
+		'C': {"20"},
+		'K': {"30"},
+		'T': {"40", "41", "42"},
+	}
+}
+
+func (suite *HomophonicTest) TestRoundRobin() {
+	hp, err := NewHomophonic(suite.table, RoundRobinChoice, 0)
+	suite.Nil(err)
+
+	enc, err := hp.Encode("attack at")
+	suite.Nil(err)
+	suite.Equal("10 40 41 11 20 30 10 42", enc)
+
+	dec, err := hp.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("ATTACKAT", dec)
+}
+
+func (suite *HomophonicTest) TestRandomIsSeeded() {
+	first, err := NewHomophonic(suite.table, RandomChoice, 42)
+	suite.Nil(err)
+	second, err := NewHomophonic(suite.table, RandomChoice, 42)
+	suite.Nil(err)
+
+	firstEnc, err := first.Encode(strings.Repeat("attack", 10))
+	suite.Nil(err)
+	secondEnc, err := second.Encode(strings.Repeat("attack", 10))
+	suite.Nil(err)
+	suite.Equal(firstEnc, secondEnc)
+
+	// every homophone of `T` gets used
+	for _, symbol := range suite.table['T'] {
+		suite.Contains(firstEnc, symbol)
+	}
+
+	dec, err := first.Decode(firstEnc)
+	suite.Nil(err)
+	suite.Equal(strings.Repeat("ATTACK", 10), dec)
+}
+
+func (suite *HomophonicTest) TestGenerateTable() {
+	table, err := GenerateHomophoneTable(100, 1)
+	suite.Nil(err)
+	suite.Len(table, 26)
+
+	total := 0
+	seen := map[string]bool{}
+	for _, symbols := range table {
+		suite.NotEmpty(symbols)
+		for _, symbol := range symbols {
+			suite.Len(symbol, 2)
+			suite.False(seen[symbol])
+			seen[symbol] = true
+		}
+		total += len(symbols)
+	}
+	suite.Equal(100, total)
+
+	// common letters get more homophones
+	suite.Greater(len(table['E']), len(table['T']))
+	suite.Greater(len(table['T']), len(table['Z']))
+
+	again, err := GenerateHomophoneTable(100, 1)
+	suite.Nil(err)
+	suite.Equal(table, again)
+}
+
+func (suite *HomophonicTest) TestParseTable() {
+	table, err := ParseHomophoneTable(strings.NewReader(`{"a": ["10", "11"], "B": ["12"]}`))
+	suite.Nil(err)
+	suite.Equal(map[rune][]string{'A': {"10", "11"}, 'B': {"12"}}, table)
+
+	_, err = ParseHomophoneTable(strings.NewReader(`{"AB": ["10"]}`))
+	suite.NotNil(err)
+	suite.Equal("expected a single letter: AB", err.Error())
+}
+
+func (suite *HomophonicTest) TestErrors() {
+	_, err := NewHomophonic(map[rune][]string{'A': {"1"}, 'B': {"1"}}, RandomChoice, 0)
+	suite.NotNil(err)
+	suite.Contains(err.Error(), "symbol 1 used for both")
+
+	hp, err := NewHomophonic(suite.table, RandomChoice, 0)
+	suite.Nil(err)
+
+	_, err = hp.Encode("dog")
+	suite.NotNil(err)
+	suite.Equal("no homophones for: D", err.Error())
+
+	_, err = hp.Decode("10 99")
+	suite.NotNil(err)
+	suite.Equal("unknown symbol at position 1: 99", err.Error())
+
+	_, err = GenerateHomophoneTable(20, 0)
+	suite.NotNil(err)
+	suite.Equal("need at least 26 symbols", err.Error())
+}
+
+func TestHomophonic(t *testing.T) {
+	suite.Run(t, new(HomophonicTest))
+}
@@ -0,0 +1,148 @@
+package ciphers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"io"
+	"strings"
+)
+
+// https://en.wikipedia.org/wiki/Jefferson_disk
+//
+// The message is set along the line of wheels, a block at a time, and
+// the ciphertext is read from the line `offset` places further round.
+type JeffersonWheel struct {
+	// wheels in the order they are stacked on the axle
+	wheels []*lookup.Alphabet
+	// line read off as ciphertext, 1 to 25
+	offset int
+	Encoder
+	Decoder
+}
+
+// Reads a wheel set, one wheel per line as a mixed arrangement of the
+// 26 letters A to Z, e.g. the 25 wheels of the US Army M-94. Blank lines
+// and lines starting with `#` are skipped.
+func ParseWheels(r io.Reader) ([]string, error) {
+	wheels := []string{}
+	straight := lookup.Latin
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.ToUpper(strings.TrimSpace(scanner.Text()))
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if len([]rune(line)) != 26 {
+			return nil, fmt.Errorf("wheel on line %d: expected 26 letters", lineNum)
+		}
+		for _, c := range line {
+			if !straight.Contains(c) {
+				return nil, fmt.Errorf("wheel on line %d: not a letter: %s", lineNum, string(c))
+			}
+		}
+		if _, err := lookup.NewAlphabet(line); err != nil {
+			return nil, fmt.Errorf("wheel on line %d: %s", lineNum, err.Error())
+		}
+
+		wheels = append(wheels, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(wheels) == 0 {
+		return nil, errors.New("no wheels found")
+	}
+
+	return wheels, nil
+}
+
+func (j *JeffersonWheel) validate() error {
+	if len(j.wheels) == 0 {
+		return errors.New("no wheels")
+	}
+	if j.offset < 1 || j.offset > 25 {
+		return errors.New("expected line offset between 1 and 25")
+	}
+	return nil
+}
+
+// Moves each letter around its wheel, and splits the result into one
+// group per turn of the wheels.
+func (j *JeffersonWheel) turn(s string, offset int) (string, error) {
+	str := []rune(prepareInput(s))
+	groups := []string{}
+
+	for i := 0; i < len(str); i += len(j.wheels) {
+		group := make([]rune, 0, len(j.wheels))
+		for k := i; k < len(str) && k < i+len(j.wheels); k++ {
+			moved, err := j.wheels[k-i].Move(str[k], offset)
+			if err != nil {
+				return "", err
+			}
+			group = append(group, moved)
+		}
+		groups = append(groups, string(group))
+	}
+
+	return strings.Join(groups, " "), nil
+}
+
+func (j *JeffersonWheel) Encode(s string) (string, error) {
+	if err := j.validate(); err != nil {
+		return "", err
+	}
+
+	encoded, err := j.turn(s, j.offset)
+	if err != nil {
+		return "", errors.New("encoding failed")
+	}
+
+	return encoded, nil
+}
+
+func (j *JeffersonWheel) Decode(s string) (string, error) {
+	if err := j.validate(); err != nil {
+		return "", err
+	}
+
+	decoded, err := j.turn(s, -j.offset)
+	if err != nil {
+		return "", errors.New("decoding failed")
+	}
+
+	return decoded, nil
+}
+
+// `order` lists the 1-based numbers of the wheels in the order they go
+// on the axle, which is the key. An empty order uses every wheel in turn.
+func NewJeffersonWheel(wheels []string, order []int, offset int) (*JeffersonWheel, error) {
+	if len(order) == 0 {
+		for i := range wheels {
+			order = append(order, i+1)
+		}
+	}
+
+	rings := make([]*lookup.Alphabet, len(order))
+	for i, num := range order {
+		if num < 1 || num > len(wheels) {
+			return nil, fmt.Errorf("no wheel number %d", num)
+		}
+		ring, err := lookup.NewAlphabet(strings.ToUpper(wheels[num-1]))
+		if err != nil {
+			return nil, fmt.Errorf("wheel %d: %s", num, err.Error())
+		}
+		rings[i] = ring
+	}
+
+	return &JeffersonWheel{
+		wheels: rings,
+		offset: offset,
+	}, nil
+}
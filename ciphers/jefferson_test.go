@@ -0,0 +1,114 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+var testWheels = []string{
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"ZYXWVUTSRQPONMLKJIHGFEDCBA",
+	"QWERTYUIOPASDFGHJKLZXCVBNM",
+}
+
+type jeffersonCase struct {
+	order   []int
+	offset  int
+	plain   string
+	encoded string
+	decoded string
+}
+
+type JeffersonTest struct {
+	suite.Suite
+	cases []*jeffersonCase
+}
+
+func (suite *JeffersonTest) SetupTest() {
+	suite.cases = []*jeffersonCase{
+		{
+			order:   []int{},
+			offset:  1,
+			plain:   "aaa q",
+			encoded: "BZS R",
+			decoded: "AAA Q",
+		},
+		{
+			order:   []int{3, 1, 2},
+			offset:  1,
+			plain:   "AAA",
+			encoded: "SBZ",
+			decoded: "AAA",
+		},
+		{
+			order:   []int{2, 2},
+			offset:  25,
+			plain:   "zebra",
+			encoded: "AF CS B",
+			decoded: "ZE BR A",
+		},
+	}
+}
+
+func (suite *JeffersonTest) TestEncoding() {
+	for _, cs := range suite.cases {
+		jw, err := NewJeffersonWheel(testWheels, cs.order, cs.offset)
+		suite.Nil(err)
+
+		enc, err := jw.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *JeffersonTest) TestDecoding() {
+	for _, cs := range suite.cases {
+		jw, err := NewJeffersonWheel(testWheels, cs.order, cs.offset)
+		suite.Nil(err)
+
+		dec, err := jw.Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.decoded, dec)
+	}
+}
+
+func (suite *JeffersonTest) TestParseWheels() {
+	wheels, err := ParseWheels(strings.NewReader(
+		"# test wheels\n\n" + strings.Join(testWheels, "\n") + "\n",
+	))
+	suite.Nil(err)
+	suite.Equal(testWheels, wheels)
+
+	_, err = ParseWheels(strings.NewReader("ABC\n"))
+	suite.NotNil(err)
+	suite.Equal("wheel on line 1: expected 26 letters", err.Error())
+
+	_, err = ParseWheels(strings.NewReader("# comment\nABCDEFGHIJKLMNOPQRSTUVWXYA\n"))
+	suite.NotNil(err)
+	suite.Equal("wheel on line 2: duplicate letter in alphabet: A", err.Error())
+
+	_, err = ParseWheels(strings.NewReader("ABCDEFGHIJKLMNOPQRSTUVWXY1\n"))
+	suite.NotNil(err)
+	suite.Equal("wheel on line 1: not a letter: 1", err.Error())
+
+	_, err = ParseWheels(strings.NewReader("# nothing here\n"))
+	suite.NotNil(err)
+	suite.Equal("no wheels found", err.Error())
+}
+
+func (suite *JeffersonTest) TestErrors() {
+	_, err := NewJeffersonWheel(testWheels, []int{4}, 1)
+	suite.NotNil(err)
+	suite.Equal("no wheel number 4", err.Error())
+
+	jw, err := NewJeffersonWheel(testWheels, nil, 26)
+	suite.Nil(err)
+	_, err = jw.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("expected line offset between 1 and 25", err.Error())
+}
+
+func TestJefferson(t *testing.T) {
+	suite.Run(t, new(JeffersonTest))
+}
@@ -0,0 +1,110 @@
+package ciphers
+
+import "errors"
+
+// Square matrices over the integers mod m, for the Hill cipher. Entries
+// are kept between 0 and m-1.
+
+// `a` mod `m`, between 0 and m-1 even for negative `a`.
+func mod(a int, m int) int {
+	return ((a % m) + m) % m
+}
+
+// The x with a·x ≡ 1 mod m, found by the extended Euclidean algorithm.
+// There is none unless `a` and `m` share no factor.
+func modInverse(a int, m int) (int, bool) {
+	a = mod(a, m)
+	oldR, r := a, m
+	oldS, s := 1, 0
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldS, s = s, oldS-q*s
+	}
+	if oldR != 1 {
+		return 0, false
+	}
+	return mod(oldS, m), true
+}
+
+// The matrix without row `row` and column `col`.
+func minor(a [][]int, row int, col int) [][]int {
+	out := make([][]int, 0, len(a)-1)
+	for r := range a {
+		if r == row {
+			continue
+		}
+		line := make([]int, 0, len(a)-1)
+		for c := range a[r] {
+			if c != col {
+				line = append(line, a[r][c])
+			}
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// Determinant mod m by cofactor expansion along the first row, which is
+// quick enough for the small blocks Hill ciphers use.
+func determinant(a [][]int, m int) int {
+	switch len(a) {
+	case 0:
+		return 1
+	case 1:
+		return mod(a[0][0], m)
+	case 2:
+		return mod(a[0][0]*a[1][1]-a[0][1]*a[1][0], m)
+	}
+
+	det := 0
+	sign := 1
+	for c := range a[0] {
+		det = mod(det+sign*a[0][c]*determinant(minor(a, 0, c), m), m)
+		sign = -sign
+	}
+	return det
+}
+
+// The inverse mod m, as the adjugate times the inverse of the
+// determinant. Only matrices whose determinant shares no factor with m
+// have one.
+func invertMatrix(a [][]int, m int) ([][]int, error) {
+	detInverse, ok := modInverse(determinant(a, m), m)
+	if !ok {
+		return nil, errors.New("matrix is not invertible")
+	}
+
+	n := len(a)
+	inverse := make([][]int, n)
+	for r := range inverse {
+		inverse[r] = make([]int, n)
+	}
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			cofactor := determinant(minor(a, r, c), m)
+			if (r+c)%2 == 1 {
+				cofactor = -cofactor
+			}
+			// the adjugate is the transpose of the cofactors
+			inverse[c][r] = mod(cofactor*detInverse, m)
+		}
+	}
+	return inverse, nil
+}
+
+// a·b mod m, for `a` with as many columns as `b` has rows.
+func multiplyMatrices(a [][]int, b [][]int, m int) [][]int {
+	out := make([][]int, len(a))
+	for r := range a {
+		out[r] = make([]int, len(b[0]))
+		for c := range b[0] {
+			sum := 0
+			for k := range b {
+				sum += a[r][k] * b[k][c]
+			}
+			out[r][c] = mod(sum, m)
+		}
+	}
+	return out
+}
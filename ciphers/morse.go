@@ -0,0 +1,327 @@
+package ciphers
+
+import (
+	"errors"
+	"fmt"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"strings"
+	"unicode"
+)
+
+// https://en.wikipedia.org/wiki/Morse_code
+type Morse struct {
+	// written between the codes of letters in a word
+	letterSep string
+	// written between words
+	wordSep string
+	// decode codes shared with punctuation as prosigns, e.g. `<AR>`
+	// instead of `+`
+	prosigns bool
+	// reverse lookup, code to text
+	decodeTable map[string]string
+	Encoder
+	Decoder
+}
+
+// A group of dots and dashes with no meaning, and its byte offset in the
+// decoded input.
+type UnknownGroup struct {
+	Position int
+	Group    string
+}
+
+// Returned alongside the rest of the decoded message when some groups
+// couldn't be decoded. Each one is replaced by `?` in the message.
+type UnknownGroupsError struct {
+	Groups []UnknownGroup
+}
+
+func (e *UnknownGroupsError) Error() string {
+	groups := make([]string, len(e.Groups))
+	for i, g := range e.Groups {
+		groups[i] = fmt.Sprintf("%s at %d", g.Group, g.Position)
+	}
+	return "unknown Morse groups: " + strings.Join(groups, ", ")
+}
+
+// Decoding finds letters and words by their separators, so neither can
+// be empty.
+func (m *Morse) checkSeparators() error {
+	if len(m.letterSep) == 0 || len(m.wordSep) == 0 {
+		return errors.New("letter and word separators must not be empty")
+	}
+	return nil
+}
+
+// Encode writes prosigns given as e.g. `<SK>` run together. Whitespace
+// separates words.
+func (m *Morse) Encode(s string) (string, error) {
+	if err := m.checkSeparators(); err != nil {
+		return "", err
+	}
+	words := strings.Fields(strings.ToUpper(s))
+	encodedWords := make([]string, len(words))
+
+	for i, word := range words {
+		codes := []string{}
+		for len(word) > 0 {
+			if word[0] == '<' {
+				end := strings.IndexRune(word, '>')
+				if end > 0 {
+					code, ok := lookup.MorseProsigns[word[1:end]]
+					if !ok {
+						return "", fmt.Errorf("unknown prosign: %s", word[:end+1])
+					}
+					codes = append(codes, code)
+					word = word[end+1:]
+					continue
+				}
+			}
+
+			c := []rune(word)[0]
+			code, ok := lookup.MorseCode[c]
+			if !ok {
+				return "", fmt.Errorf("no Morse code for: %s", string(c))
+			}
+			codes = append(codes, code)
+			word = word[len(string(c)):]
+		}
+		encodedWords[i] = strings.Join(codes, m.letterSep)
+	}
+
+	return strings.Join(encodedWords, m.wordSep), nil
+}
+
+// Decode returns everything it can decode. If any groups are unknown,
+// it also returns an *UnknownGroupsError listing where they are.
+func (m *Morse) Decode(s string) (string, error) {
+	if err := m.checkSeparators(); err != nil {
+		return "", err
+	}
+	s = strings.TrimRightFunc(s, unicode.IsSpace)
+	var decoded strings.Builder
+	unknown := []UnknownGroup{}
+	pos := 0
+
+	for pos < len(s) {
+		switch {
+		case strings.HasPrefix(s[pos:], m.wordSep):
+			decoded.WriteRune(' ')
+			pos += len(m.wordSep)
+		case strings.HasPrefix(s[pos:], m.letterSep):
+			pos += len(m.letterSep)
+		default:
+			end := len(s)
+			for _, sep := range []string{m.wordSep, m.letterSep} {
+				if i := strings.Index(s[pos:], sep); i > -1 && pos+i < end {
+					end = pos + i
+				}
+			}
+
+			group := s[pos:end]
+			if text, ok := m.decodeTable[group]; ok {
+				decoded.WriteString(text)
+			} else {
+				decoded.WriteRune('?')
+				unknown = append(unknown, UnknownGroup{Position: pos, Group: group})
+			}
+			pos = end
+		}
+	}
+
+	if len(unknown) > 0 {
+		return decoded.String(), &UnknownGroupsError{Groups: unknown}
+	}
+	return decoded.String(), nil
+}
+
+// Letters and words are usually separated by " " and " / ". With
+// `prosigns`, codes shared by prosigns and punctuation decode as the
+// prosign.
+func NewMorse(letterSep string, wordSep string, prosigns bool) *Morse {
+	table := map[string]string{}
+	for c, code := range lookup.MorseCode {
+		table[code] = string(c)
+	}
+	for sign, code := range lookup.MorseProsigns {
+		if _, ok := table[code]; !ok || prosigns {
+			table[code] = "<" + sign + ">"
+		}
+	}
+
+	return &Morse{
+		letterSep:   letterSep,
+		wordSep:     wordSep,
+		prosigns:    prosigns,
+		decodeTable: table,
+	}
+}
+
+// Morse for the fractionating ciphers: `x` after each letter and
+// another after each word, e.g. `HI MOM` gives `....x..xx--x---x--`.
+func fractionMorse(s string) (string, error) {
+	words := strings.Fields(strings.ToUpper(s))
+	encodedWords := make([]string, len(words))
+
+	for i, word := range words {
+		codes := []string{}
+		for _, c := range word {
+			code, ok := lookup.MorseCode[c]
+			if !ok {
+				return "", fmt.Errorf("no Morse code for: %s", string(c))
+			}
+			codes = append(codes, code)
+		}
+		encodedWords[i] = strings.Join(codes, "x")
+	}
+
+	return strings.Join(encodedWords, "xx"), nil
+}
+
+// Reads Morse written by fractionMorse. Groups that aren't Morse letters
+// come back as `?` along with an *UnknownGroupsError.
+func fromFractionMorse(m string) (string, error) {
+	morse := NewMorse("x", "xx", false)
+	return morse.Decode(strings.TrimRight(m, "x"))
+}
+
+// ACA Fractionated Morse: the Morse is read in threes, and each of the
+// 26 possible trigrams is replaced by a letter of a keyed alphabet.
+type FractionatedMorse struct {
+	key      string
+	alphabet *lookup.Alphabet
+	Encoder
+	Decoder
+}
+
+// Trigrams in alphabet order, `...` is the first letter and `xx-` the last.
+const fractionSymbols = ".-x"
+
+func (f *FractionatedMorse) Encode(s string) (string, error) {
+	morse, err := fractionMorse(s)
+	if err != nil {
+		return "", err
+	}
+	for len(morse)%3 != 0 {
+		morse += "x"
+	}
+
+	encoded := make([]rune, 0, len(morse)/3)
+	for i := 0; i < len(morse); i += 3 {
+		idx := 0
+		for _, sym := range morse[i : i+3] {
+			idx = idx*3 + strings.IndexRune(fractionSymbols, sym)
+		}
+		encoded = append(encoded, f.alphabet.At(idx))
+	}
+
+	return string(encoded), nil
+}
+
+func (f *FractionatedMorse) Decode(s string) (string, error) {
+	var morse strings.Builder
+	for _, c := range prepareInput(s) {
+		idx := f.alphabet.Index(c)
+		morse.WriteByte(fractionSymbols[idx/9])
+		morse.WriteByte(fractionSymbols[idx/3%3])
+		morse.WriteByte(fractionSymbols[idx%3])
+	}
+
+	decoded, err := fromFractionMorse(morse.String())
+	if err != nil {
+		return decoded, fmt.Errorf("decoding failed: %w", err)
+	}
+	return decoded, nil
+}
+
+func NewFractionatedMorse(key string) *FractionatedMorse {
+	return &FractionatedMorse{
+		key:      key,
+		alphabet: lookup.NewKeyedAlphabet(key, lookup.Latin),
+	}
+}
+
+// ACA Morbit: the Morse is read in pairs, and each of the 9 possible
+// pairs is replaced by a digit given by the numeric order of a 9 letter
+// key.
+type Morbit struct {
+	key string
+	// digit for each pair, in the order `..`, `.-`, `.x`, `-.` ... `xx`
+	digits []int
+	Encoder
+	Decoder
+}
+
+func (m *Morbit) validate() error {
+	if len(m.digits) != 9 {
+		return errors.New("key must have 9 letters")
+	}
+	return nil
+}
+
+// Encode returns the digits in groups of five.
+func (m *Morbit) Encode(s string) (string, error) {
+	if err := m.validate(); err != nil {
+		return "", err
+	}
+
+	morse, err := fractionMorse(s)
+	if err != nil {
+		return "", err
+	}
+	if len(morse)%2 != 0 {
+		morse += "x"
+	}
+
+	digits := make([]byte, 0, len(morse)/2)
+	for i := 0; i < len(morse); i += 2 {
+		idx := strings.IndexByte(fractionSymbols, morse[i])*3 +
+			strings.IndexByte(fractionSymbols, morse[i+1])
+		digits = append(digits, byte('0'+m.digits[idx]))
+	}
+
+	groups := []string{}
+	for i := 0; i < len(digits); i += 5 {
+		groups = append(groups, string(digits[i:min(i+5, len(digits))]))
+	}
+	return strings.Join(groups, " "), nil
+}
+
+// Decode ignores anything but digits.
+func (m *Morbit) Decode(s string) (string, error) {
+	if err := m.validate(); err != nil {
+		return "", err
+	}
+
+	var morse strings.Builder
+	for _, d := range s {
+		if d < '1' || d > '9' {
+			continue
+		}
+		for idx, digit := range m.digits {
+			if digit == int(d-'0') {
+				morse.WriteByte(fractionSymbols[idx/3])
+				morse.WriteByte(fractionSymbols[idx%3])
+			}
+		}
+	}
+
+	decoded, err := fromFractionMorse(morse.String())
+	if err != nil {
+		return decoded, fmt.Errorf("decoding failed: %w", err)
+	}
+	return decoded, nil
+}
+
+func NewMorbit(key string) *Morbit {
+	order := keyOrder(prepareInput(key))
+	digits := make([]int, len(order))
+	for i, rank := range order {
+		digits[i] = rank + 1
+	}
+
+	return &Morbit{
+		key:    key,
+		digits: digits,
+	}
+}
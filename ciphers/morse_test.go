@@ -0,0 +1,136 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type morseCase struct {
+	morse   *Morse
+	plain   string
+	encoded string
+	decoded string
+}
+
+type MorseTest struct {
+	suite.Suite
+	cases []*morseCase
+}
+
+func (suite *MorseTest) SetupTest() {
+	suite.cases = []*morseCase{
+		{
+			morse:   NewMorse(" ", " / ", false),
+			plain:   "sos, send help",
+			encoded: "... --- ... --..-- / ... . -. -.. / .... . .-.. .--.",
+			decoded: "SOS, SEND HELP",
+		},
+		{
+			morse:   NewMorse("|", "||", true),
+			plain:   "QRV? <AR> 73 <SK>",
+			encoded: "--.-|.-.|...-|..--..||.-.-.||--...|...--||...-.-",
+			decoded: "QRV? <AR> 73 <SK>",
+		},
+		// without prosigns, shared codes decode as punctuation
+		{
+			morse:   NewMorse(" ", " / ", false),
+			plain:   "1 <AR> 2",
+			encoded: ".---- / .-.-. / ..---",
+			decoded: "1 + 2",
+		},
+	}
+}
+
+func (suite *MorseTest) TestEncoding() {
+	for _, cs := range suite.cases {
+		enc, err := cs.morse.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *MorseTest) TestDecoding() {
+	for _, cs := range suite.cases {
+		dec, err := cs.morse.Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.decoded, dec)
+	}
+}
+
+func (suite *MorseTest) TestUnknownGroups() {
+	morse := NewMorse(" ", " / ", false)
+	dec, err := morse.Decode(".... .. / ..--.--- / -- --- ......--\n")
+	suite.Equal("HI ? MO?", dec)
+
+	unknown := &UnknownGroupsError{}
+	suite.ErrorAs(err, &unknown)
+	suite.Equal([]UnknownGroup{
+		{Position: 10, Group: "..--.---"},
+		{Position: 28, Group: "......--"},
+	}, unknown.Groups)
+	suite.Equal("unknown Morse groups: ..--.--- at 10, ......-- at 28", err.Error())
+}
+
+func (suite *MorseTest) TestFractionatedMorse() {
+	fm := NewFractionatedMorse("roundtable")
+
+	enc, err := fm.Encode("Come at once")
+	suite.Nil(err)
+	suite.Equal("CBIILTMHVVFL", enc)
+
+	dec, err := fm.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("COME AT ONCE", dec)
+}
+
+func (suite *MorseTest) TestMorbit() {
+	mb := NewMorbit("wisecrack")
+
+	enc, err := mb.Encode("Once upon a time")
+	suite.Nil(err)
+	suite.Equal("27435 88151 28274 65679 378", enc)
+
+	dec, err := mb.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("ONCE UPON A TIME", dec)
+}
+
+func (suite *MorseTest) TestFractionUnknownGroups() {
+	unknown := &UnknownGroupsError{}
+
+	// `RRR` is nine dots
+	dec, err := NewFractionatedMorse("roundtable").Decode("CBIILTRRRMHVVFL")
+	suite.Equal("COME A? ONCE", dec)
+	suite.ErrorAs(err, &unknown)
+	suite.Equal("decoding failed: unknown Morse groups: .........- at 18", err.Error())
+
+	dec, err = NewMorbit("wisecrack").Decode("27435 88151 22222 28274 65679 378")
+	suite.Equal("ONCE U?ON A TIME", dec)
+	suite.ErrorAs(err, &unknown)
+	suite.Equal([]UnknownGroup{{Position: 19, Group: ".------------."}}, unknown.Groups)
+}
+
+func (suite *MorseTest) TestErrors() {
+	morse := NewMorse(" ", " / ", false)
+	_, err := morse.Encode("naïve")
+	suite.NotNil(err)
+	suite.Equal("no Morse code for: Ï", err.Error())
+
+	_, err = morse.Encode("<XY>")
+	suite.NotNil(err)
+	suite.Equal("unknown prosign: <XY>", err.Error())
+
+	_, err = NewMorse("", " / ", false).Decode(".- -...")
+	suite.Equal("letter and word separators must not be empty", err.Error())
+	_, err = NewMorse(" ", "", false).Encode("ab")
+	suite.Equal("letter and word separators must not be empty", err.Error())
+
+	mb := NewMorbit("short")
+	_, err = mb.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("key must have 9 letters", err.Error())
+}
+
+func TestMorse(t *testing.T) {
+	suite.Run(t, new(MorseTest))
+}
@@ -0,0 +1,87 @@
+package ciphers
+
+import (
+	"errors"
+)
+
+// https://www.cryptogram.org/resource-area/cipher-types/
+//
+// The message is written in rows under the key and each column is
+// enciphered with Vigenère using its key letter. The columns are put in
+// the key's alphabetical order and read off downwards five rows at a
+// time, block by block.
+type Nicodemus struct {
+	key string
+	polyalphabetic
+	Encoder
+	Decoder
+}
+
+const nicodemusBlockRows = 5
+
+// Message positions in the order they're read off, for a message of
+// `length` letters under `key`.
+func (n *Nicodemus) readingOrder(key []rune, length int) []int {
+	width := len(key)
+	columns := make([]int, width)
+	for col, rank := range keyOrder(string(key)) {
+		columns[rank] = col
+	}
+
+	order := make([]int, 0, length)
+	for block := 0; block*width*nicodemusBlockRows < length; block++ {
+		for _, col := range columns {
+			for row := block * nicodemusBlockRows; row < (block+1)*nicodemusBlockRows; row++ {
+				if pos := row*width + col; pos < length {
+					order = append(order, pos)
+				}
+			}
+		}
+	}
+	return order
+}
+
+func (n *Nicodemus) Encode(s string) (string, error) {
+	key := []rune(n.alphabet.Filter(n.key))
+	if len(key) == 0 {
+		return "", errors.New("empty key")
+	}
+
+	str := []rune(n.alphabet.Filter(s))
+	encoded := make([]rune, 0, len(str))
+	for _, pos := range n.readingOrder(key, len(str)) {
+		c, err := n.shiftChar(str[pos], n.offset(key[pos%len(key)]))
+		if err != nil {
+			return "", errors.New("encoding failed")
+		}
+		encoded = append(encoded, c)
+	}
+
+	return string(encoded), nil
+}
+
+func (n *Nicodemus) Decode(s string) (string, error) {
+	key := []rune(n.alphabet.Filter(n.key))
+	if len(key) == 0 {
+		return "", errors.New("empty key")
+	}
+
+	str := []rune(n.alphabet.Filter(s))
+	decoded := make([]rune, len(str))
+	for i, pos := range n.readingOrder(key, len(str)) {
+		c, err := n.shiftChar(str[i], -n.offset(key[pos%len(key)]))
+		if err != nil {
+			return "", errors.New("decoding failed")
+		}
+		decoded[pos] = c
+	}
+
+	return string(decoded), nil
+}
+
+func NewNicodemus(key string, opts ...Option) *Nicodemus {
+	return &Nicodemus{
+		key:            key,
+		polyalphabetic: newPolyalphabetic(newOptions(opts).alphabet),
+	}
+}
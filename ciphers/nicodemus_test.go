@@ -0,0 +1,45 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type NicodemusTest struct {
+	suite.Suite
+	nicodemus *Nicodemus
+}
+
+func (suite *NicodemusTest) SetupTest() {
+	suite.nicodemus = NewNicodemus("CAT")
+}
+
+func (suite *NicodemusTest) TestEncoding() {
+	enc, err := suite.nicodemus.Encode("The quick brown fox jumps")
+	suite.Nil(err)
+	suite.Equal("HUKOFVSETPXBUPHJPZONL", enc)
+
+	// the last row can be short
+	enc, err = suite.nicodemus.Encode("THEQUICKBROWNFOXJUMPSO")
+	suite.Nil(err)
+	suite.Equal("HUKOFVSETPXBUPHJPZOQNL", enc)
+}
+
+func (suite *NicodemusTest) TestDecoding() {
+	dec, err := suite.nicodemus.Decode("HUKOFVSETPXBUPHJPZONL")
+	suite.Nil(err)
+	suite.Equal("THEQUICKBROWNFOXJUMPS", dec)
+
+	dec, err = suite.nicodemus.Decode("HUKOFVSETPXBUPHJPZOQNL")
+	suite.Nil(err)
+	suite.Equal("THEQUICKBROWNFOXJUMPSO", dec)
+}
+
+func (suite *NicodemusTest) TestErrors() {
+	_, err := NewNicodemus("").Encode("text")
+	suite.EqualError(err, "empty key")
+}
+
+func TestNicodemus(t *testing.T) {
+	suite.Run(t, new(NicodemusTest))
+}
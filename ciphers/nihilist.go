@@ -0,0 +1,265 @@
+package ciphers
+
+import (
+	"errors"
+	"fmt"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// https://en.wikipedia.org/wiki/Nihilist_cipher
+type NihilistSubstitution struct {
+	// keyword used to mix the Polybius square
+	squareKey string
+	// additive key, repeated over the message
+	key string
+	// 5 x 5 Polybius square built from squareKey, with 'J' merged into 'I'
+	grid [5][5]rune
+	Encoder
+	Decoder
+}
+
+// https://en.wikipedia.org/wiki/Transposition_cipher
+type NihilistTransposition struct {
+	key string
+	// numeric order of the key letters, used for both rows and columns
+	order []int
+	// letters kept from the message
+	alphabet *lookup.Alphabet
+	Encoder
+	Decoder
+}
+
+// Nihilist ciphers share Playfair's 25 letter square, so 'J' is folded
+// into 'I' before building the grid or looking up letters in it.
+func prepareNihilistInput(input string) string {
+	return strings.ReplaceAll(prepareInput(input), "J", "I")
+}
+
+// Numeric order of each letter in the key, e.g. `ZEBRAS` gives
+// [5 2 1 3 0 4]. Repeated letters are numbered left to right.
+func keyOrder(key string) []int {
+	letters := []rune(key)
+	sorted := make([]int, len(letters))
+	for i := range sorted {
+		sorted[i] = i
+	}
+	slices.SortStableFunc(sorted, func(a, b int) int {
+		return int(letters[a]) - int(letters[b])
+	})
+
+	order := make([]int, len(letters))
+	for rank, pos := range sorted {
+		order[pos] = rank
+	}
+	return order
+}
+
+// Polybius coordinates of a letter as a two digit number, e.g. row 2,
+// column 3 gives 23.
+func (n *NihilistSubstitution) coordinates(c rune) (int, error) {
+	for i, row := range n.grid {
+		for j, cell := range row {
+			if cell == c {
+				return (i+1)*10 + j + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("letter not present in square: %s", string(c))
+}
+
+func (n *NihilistSubstitution) letter(coords int) (rune, error) {
+	i, j := coords/10-1, coords%10-1
+	if i < 0 || i > 4 || j < 0 || j > 4 {
+		return 0, fmt.Errorf("not a square coordinate: %d", coords)
+	}
+	return n.grid[i][j], nil
+}
+
+func (n *NihilistSubstitution) keyNumbers() ([]int, error) {
+	key := prepareNihilistInput(n.key)
+	if len(key) == 0 {
+		return nil, errors.New("empty key")
+	}
+
+	numbers := make([]int, len(key))
+	for i, c := range key {
+		coords, err := n.coordinates(c)
+		if err != nil {
+			return nil, err
+		}
+		numbers[i] = coords
+	}
+	return numbers, nil
+}
+
+// Encode returns the sums of plaintext and key coordinates, separated
+// by spaces, e.g. `37 106 62 36`.
+func (n *NihilistSubstitution) Encode(input string) (string, error) {
+	keyNums, err := n.keyNumbers()
+	if err != nil {
+		return "", err
+	}
+
+	str := []rune(prepareNihilistInput(input))
+	numbers := make([]string, len(str))
+	wg := sync.WaitGroup{}
+	errCount := 0
+
+	encFunc := func(c rune, pos int, wg *sync.WaitGroup) {
+		defer wg.Done()
+		coords, err := n.coordinates(c)
+		if err != nil {
+			errCount++
+			return
+		}
+		numbers[pos] = strconv.Itoa(coords + keyNums[pos%len(keyNums)])
+	}
+
+	for i, c := range str {
+		wg.Add(1)
+		go encFunc(c, i, &wg)
+	}
+
+	wg.Wait()
+	if errCount > 0 {
+		return "", errors.New("encoding failed")
+	}
+
+	return strings.Join(numbers, " "), nil
+}
+
+// Decode expects whitespace separated numbers, as produced by Encode.
+func (n *NihilistSubstitution) Decode(input string) (string, error) {
+	keyNums, err := n.keyNumbers()
+	if err != nil {
+		return "", err
+	}
+
+	groups := strings.Fields(input)
+	numbers := make([]int, len(groups))
+	for i, g := range groups {
+		num, convErr := strconv.Atoi(g)
+		if convErr != nil {
+			return "", fmt.Errorf("not a number at position %d: %s", i, g)
+		}
+		numbers[i] = num
+	}
+
+	runes := make([]rune, len(numbers))
+	wg := sync.WaitGroup{}
+	errCount := 0
+
+	decFunc := func(num int, pos int, wg *sync.WaitGroup) {
+		defer wg.Done()
+		dec, err := n.letter(num - keyNums[pos%len(keyNums)])
+		if err != nil {
+			errCount++
+		}
+		runes[pos] = dec
+	}
+
+	for i, num := range numbers {
+		wg.Add(1)
+		go decFunc(num, i, &wg)
+	}
+
+	wg.Wait()
+	if errCount > 0 {
+		return "", errors.New("decoding failed")
+	}
+
+	return string(runes), nil
+}
+
+func NewNihilistSubstitution(squareKey string, key string) *NihilistSubstitution {
+	grid, _ := gridFromKey([]rune(prepareNihilistInput(squareKey)), lookup.Latin, 'J')
+	return &NihilistSubstitution{
+		squareKey: squareKey,
+		key:       key,
+		grid:      grid,
+	}
+}
+
+// Writes a block of size*size letters into a square by rows, then moves
+// row r and column c to the positions given by the key order. The
+// reverse transposition moves them back.
+func (n *NihilistTransposition) transposeBlock(block []rune, reverse bool) []rune {
+	size := len(n.order)
+	out := make([]rune, len(block))
+
+	for r := 0; r < size; r++ {
+		for c := 0; c < size; c++ {
+			from := r*size + c
+			to := n.order[r]*size + n.order[c]
+			if reverse {
+				from, to = to, from
+			}
+			out[to] = block[from]
+		}
+	}
+	return out
+}
+
+// Splits the transposed text into groups of one square row.
+func (n *NihilistTransposition) groups(runes []rune) string {
+	size := len(n.order)
+	groups := make([]string, 0, len(runes)/size)
+	for i := 0; i < len(runes); i += size {
+		groups = append(groups, string(runes[i:i+size]))
+	}
+	return strings.Join(groups, " ")
+}
+
+// Encode pads the message with 'X', or the last letter of an alphabet
+// without one, to fill whole squares.
+func (n *NihilistTransposition) Encode(input string) (string, error) {
+	if len(n.order) < 2 {
+		return "", errors.New("key must have at least 2 letters")
+	}
+
+	size := len(n.order)
+	str := []rune(n.alphabet.Filter(input))
+	for len(str)%(size*size) != 0 {
+		str = append(str, paddingLetter(n.alphabet))
+	}
+
+	encoded := make([]rune, 0, len(str))
+	for i := 0; i < len(str); i += size * size {
+		encoded = append(encoded, n.transposeBlock(str[i:i+size*size], false)...)
+	}
+
+	return n.groups(encoded), nil
+}
+
+func (n *NihilistTransposition) Decode(input string) (string, error) {
+	if len(n.order) < 2 {
+		return "", errors.New("key must have at least 2 letters")
+	}
+
+	size := len(n.order)
+	str := []rune(n.alphabet.Filter(input))
+	if len(str)%(size*size) != 0 {
+		return "", fmt.Errorf("ciphertext length must be a multiple of %d", size*size)
+	}
+
+	decoded := make([]rune, 0, len(str))
+	for i := 0; i < len(str); i += size * size {
+		decoded = append(decoded, n.transposeBlock(str[i:i+size*size], true)...)
+	}
+
+	return n.groups(decoded), nil
+}
+
+// The key is written in the cipher's alphabet.
+func NewNihilistTransposition(key string, opts ...Option) *NihilistTransposition {
+	alphabet := newOptions(opts).alphabet
+	return &NihilistTransposition{
+		key:      key,
+		order:    alphabetKeyOrder(key, alphabet),
+		alphabet: alphabet,
+	}
+}
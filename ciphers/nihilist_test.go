@@ -0,0 +1,121 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type nihilistSubstitutionCase struct {
+	squareKey string
+	key       string
+	plain     string
+	encoded   string
+	decoded   string
+}
+
+type nihilistTranspositionCase struct {
+	key     string
+	plain   string
+	encoded string
+	decoded string
+}
+
+type NihilistTest struct {
+	suite.Suite
+	substitutionCases  []*nihilistSubstitutionCase
+	transpositionCases []*nihilistTranspositionCase
+}
+
+func (suite *NihilistTest) SetupTest() {
+	suite.substitutionCases = []*nihilistSubstitutionCase{
+		{
+			squareKey: "zebras",
+			key:       "russian",
+			plain:     "DYNAMITE WINTER PALACE",
+			encoded:   "37 106 62 36 67 47 86 26 104 53 62 77 27 55 57 66 55 36 54 27",
+			decoded:   "DYNAMITEWINTERPALACE",
+		},
+		// 'J' shares a cell with 'I'
+		{
+			squareKey: "zebras",
+			key:       "jam",
+			plain:     "jim",
+			encoded:   "64 47 70",
+			decoded:   "IIM",
+		},
+	}
+
+	suite.transpositionCases = []*nihilistTranspositionCase{
+		{
+			key:     "cat",
+			plain:   "abcdefghi",
+			encoded: "EDF BAC HGI",
+			decoded: "ABC DEF GHI",
+		},
+		// short final squares are padded with 'X'
+		{
+			key:     "cat",
+			plain:   "abcdefghij",
+			encoded: "EDF BAC HGI XXX XJX XXX",
+			decoded: "ABC DEF GHI JXX XXX XXX",
+		},
+	}
+}
+
+func (suite *NihilistTest) TestKeyOrder() {
+	suite.Equal([]int{5, 2, 1, 3, 0, 4}, keyOrder("ZEBRAS"))
+	suite.Equal([]int{1, 0, 2, 3}, keyOrder("BAKK"))
+}
+
+func (suite *NihilistTest) TestSubstitution() {
+	for _, cs := range suite.substitutionCases {
+		ns := NewNihilistSubstitution(cs.squareKey, cs.key)
+
+		enc, err := ns.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+
+		dec, err := ns.Decode(enc)
+		suite.Nil(err)
+		suite.Equal(cs.decoded, dec)
+	}
+}
+
+func (suite *NihilistTest) TestTransposition() {
+	for _, cs := range suite.transpositionCases {
+		nt := NewNihilistTransposition(cs.key)
+
+		enc, err := nt.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+
+		dec, err := nt.Decode(enc)
+		suite.Nil(err)
+		suite.Equal(cs.decoded, dec)
+	}
+}
+
+func (suite *NihilistTest) TestErrors() {
+	ns := NewNihilistSubstitution("zebras", "")
+	_, err := ns.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("empty key", err.Error())
+
+	ns = NewNihilistSubstitution("zebras", "russian")
+	_, err = ns.Decode("37 1O6")
+	suite.NotNil(err)
+	suite.Equal("not a number at position 1: 1O6", err.Error())
+
+	_, err = ns.Decode("37 9")
+	suite.NotNil(err)
+	suite.Equal("decoding failed", err.Error())
+
+	nt := NewNihilistTransposition("cat")
+	_, err = nt.Decode("EDFBA")
+	suite.NotNil(err)
+	suite.Equal("ciphertext length must be a multiple of 9", err.Error())
+}
+
+func TestNihilist(t *testing.T) {
+	suite.Run(t, new(NihilistTest))
+}
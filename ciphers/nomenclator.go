@@ -0,0 +1,214 @@
+package ciphers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// https://en.wikipedia.org/wiki/Great_Cipher
+//
+// A nomenclator pairs a codebook of whole words, names and phrases with
+// a cipher alphabet used to spell out everything else. Code groups may
+// be several symbols long, e.g. `KING OF SPAIN` might be `7 43`.
+type Nomenclator struct {
+	// plaintext word or phrase to code group
+	codes map[string]string
+	// code group to plaintext word or phrase
+	reverse map[string]string
+	// longest phrase in words and longest code group in symbols
+	maxWords   int
+	maxSymbols int
+	// cipher alphabet for words not in the codebook
+	letters *Homophonic
+	Encoder
+	Decoder
+}
+
+type Codebook struct {
+	// word or phrase to code group, symbols separated by spaces
+	Codes map[string]string `json:"codes"`
+	// letter to its cipher symbols
+	Letters map[string][]string `json:"letters"`
+}
+
+// Reads a codebook from JSON, e.g.
+// `{"codes": {"QUEEN": "77 X"}, "letters": {"A": ["14", "31"]}}`.
+func ParseCodebookJSON(r io.Reader) (*Codebook, error) {
+	book := &Codebook{}
+	if err := json.NewDecoder(r).Decode(book); err != nil {
+		return nil, errors.New("could not parse codebook: " + err.Error())
+	}
+	return book, nil
+}
+
+// Reads a codebook from CSV rows of plaintext and code group. Rows for a
+// single letter make up the cipher alphabet, and may repeat to give the
+// letter several homophones. All other rows are codebook entries.
+func ParseCodebookCSV(r io.Reader) (*Codebook, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	book := &Codebook{
+		Codes:   map[string]string{},
+		Letters: map[string][]string{},
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("could not parse codebook: " + err.Error())
+		}
+
+		plain, code := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if utf8.RuneCountInString(plain) == 1 {
+			book.Letters[plain] = append(book.Letters[plain], code)
+		} else {
+			book.Codes[plain] = code
+		}
+	}
+
+	return book, nil
+}
+
+// Upper case words with anything but letters removed.
+func nomenclatorWords(s string) []string {
+	words := []string{}
+	for _, w := range strings.Fields(strings.ToUpper(s)) {
+		word := strings.Map(func(c rune) rune {
+			if unicode.IsLetter(c) {
+				return c
+			}
+			return -1
+		}, w)
+		if len(word) > 0 {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// Encode replaces the longest codebook phrases it can find, and spells
+// out the remaining words with the cipher alphabet. Symbols are
+// separated by spaces.
+func (n *Nomenclator) Encode(s string) (string, error) {
+	words := nomenclatorWords(s)
+	symbols := []string{}
+
+	for i := 0; i < len(words); {
+		matched := false
+		for size := min(n.maxWords, len(words)-i); size > 0; size-- {
+			if code, ok := n.codes[strings.Join(words[i:i+size], " ")]; ok {
+				symbols = append(symbols, code)
+				i += size
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		spelled, err := n.letters.Encode(words[i])
+		if err != nil {
+			return "", err
+		}
+		symbols = append(symbols, spelled)
+		i++
+	}
+
+	return strings.Join(symbols, " "), nil
+}
+
+// Decode greedily matches the longest code group at each position, and
+// reads any other symbol as a letter. Spelled out words aren't
+// separated, so neighbouring ones run together.
+func (n *Nomenclator) Decode(s string) (string, error) {
+	tokens := strings.Fields(s)
+	words := []string{}
+	var spelled strings.Builder
+
+	flush := func() {
+		if spelled.Len() > 0 {
+			words = append(words, spelled.String())
+			spelled.Reset()
+		}
+	}
+
+	for i := 0; i < len(tokens); {
+		matched := false
+		for size := min(n.maxSymbols, len(tokens)-i); size > 0; size-- {
+			if phrase, ok := n.reverse[strings.Join(tokens[i:i+size], " ")]; ok {
+				flush()
+				words = append(words, phrase)
+				i += size
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		letter, err := n.letters.Decode(tokens[i])
+		if err != nil {
+			return "", fmt.Errorf("unknown symbol at position %d: %s", i, tokens[i])
+		}
+		spelled.WriteString(letter)
+		i++
+	}
+	flush()
+
+	return strings.Join(words, " "), nil
+}
+
+// Homophones in the cipher alphabet are chosen as for Homophonic.
+func NewNomenclator(book *Codebook, choice HomophoneChoice, seed int64) (*Nomenclator, error) {
+	letterTable := map[rune][]string{}
+	for letter, symbols := range book.Letters {
+		if utf8.RuneCountInString(letter) != 1 {
+			return nil, fmt.Errorf("expected a single letter: %s", letter)
+		}
+		c, _ := utf8.DecodeRuneInString(letter)
+		letterTable[unicode.ToUpper(c)] = symbols
+	}
+	letters, err := NewHomophonic(letterTable, choice, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Nomenclator{
+		codes:   map[string]string{},
+		reverse: map[string]string{},
+		letters: letters,
+	}
+	for plain, code := range book.Codes {
+		phrase := strings.Join(nomenclatorWords(plain), " ")
+		group := strings.Join(strings.Fields(code), " ")
+		if len(phrase) == 0 || len(group) == 0 {
+			return nil, fmt.Errorf("empty codebook entry: %s", plain)
+		}
+		if other, ok := n.reverse[group]; ok && other != phrase {
+			return nil, fmt.Errorf("code group %s used for both %s and %s", group, other, phrase)
+		}
+		if _, ok := letters.reverse[group]; ok {
+			return nil, fmt.Errorf("code group %s is also a letter symbol", group)
+		}
+
+		n.codes[phrase] = group
+		n.reverse[group] = phrase
+		n.maxWords = max(n.maxWords, len(strings.Fields(phrase)))
+		n.maxSymbols = max(n.maxSymbols, len(strings.Fields(group)))
+	}
+
+	return n, nil
+}
@@ -0,0 +1,92 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+const testCodebookCSV = `# plaintext, code group
+QUEEN, 77
+QUEEN OF SCOTS, 77 8
+ENGLAND, 8 20
+A, 1
+A, 2
+E, 3
+L, 4
+N, 5
+S, 6
+T, 9
+`
+
+type NomenclatorTest struct {
+	suite.Suite
+	book *Codebook
+}
+
+func (suite *NomenclatorTest) SetupTest() {
+	book, err := ParseCodebookCSV(strings.NewReader(testCodebookCSV))
+	suite.Nil(err)
+	suite.book = book
+}
+
+func (suite *NomenclatorTest) TestParseCSV() {
+	suite.Equal(map[string]string{
+		"QUEEN":          "77",
+		"QUEEN OF SCOTS": "77 8",
+		"ENGLAND":        "8 20",
+	}, suite.book.Codes)
+	suite.Equal([]string{"1", "2"}, suite.book.Letters["A"])
+}
+
+func (suite *NomenclatorTest) TestParseJSON() {
+	book, err := ParseCodebookJSON(strings.NewReader(
+		`{"codes": {"QUEEN": "77"}, "letters": {"A": ["1", "2"]}}`,
+	))
+	suite.Nil(err)
+	suite.Equal(map[string]string{"QUEEN": "77"}, book.Codes)
+	suite.Equal(map[string][]string{"A": {"1", "2"}}, book.Letters)
+}
+
+func (suite *NomenclatorTest) TestEncoding() {
+	nc, err := NewNomenclator(suite.book, RoundRobinChoice, 0)
+	suite.Nil(err)
+
+	// the longest phrase wins, words not in the codebook are spelled out
+	enc, err := nc.Encode("Queen of Scots sent salt; England sent tea, Queen.")
+	suite.Nil(err)
+	suite.Equal("77 8 6 3 5 9 6 1 4 9 8 20 6 3 5 9 9 3 2 77", enc)
+}
+
+func (suite *NomenclatorTest) TestDecoding() {
+	nc, err := NewNomenclator(suite.book, RoundRobinChoice, 0)
+	suite.Nil(err)
+
+	dec, err := nc.Decode("77 8 6 3 5 9 6 2 4 9 8 20 77")
+	suite.Nil(err)
+	suite.Equal("QUEEN OF SCOTS SENTSALT ENGLAND QUEEN", dec)
+}
+
+func (suite *NomenclatorTest) TestErrors() {
+	nc, err := NewNomenclator(suite.book, RoundRobinChoice, 0)
+	suite.Nil(err)
+
+	_, err = nc.Encode("the king")
+	suite.NotNil(err)
+	suite.Equal("no homophones for: H", err.Error())
+
+	_, err = nc.Decode("77 8 6 99")
+	suite.NotNil(err)
+	suite.Equal("unknown symbol at position 3: 99", err.Error())
+
+	_, err = NewNomenclator(&Codebook{
+		Codes:   map[string]string{"KING": "1"},
+		Letters: map[string][]string{"A": {"1"}},
+	}, RoundRobinChoice, 0)
+	suite.NotNil(err)
+	suite.Equal("code group 1 is also a letter symbol", err.Error())
+}
+
+func TestNomenclator(t *testing.T) {
+	suite.Run(t, new(NomenclatorTest))
+}
@@ -0,0 +1,83 @@
+package ciphers
+
+import (
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"slices"
+)
+
+// Optional settings for the cipher constructors.
+type Option func(*options)
+
+type options struct {
+	alphabet *lookup.Alphabet
+	// letter kept and letter read as it in a 5 x 5 square
+	merged [2]rune
+}
+
+// Letters the cipher works over, in place of A to Z, e.g. lookup.Greek
+// or a keyword-mixed alphabet. Letters in the alphabet's other case are
+// enciphered within that case, and anything else passes through or is
+// dropped as it would be outside A to Z.
+//
+// Every cipher that shifts or rearranges letters takes an alphabet, as
+// does Playfair, whose square holds 25 of its letters once the merged
+// pair share a cell. Those built on a fixed square, table or matrix,
+// such as the Nihilist substitution, Hill, Bacon or Morse, are defined
+// over their own letters, as are the steganographic Cardan grille and
+// book cipher. Solvers score against English, so they stay with A to Z.
+func WithAlphabet(alphabet *lookup.Alphabet) Option {
+	return func(o *options) {
+		if alphabet != nil {
+			o.alphabet = alphabet
+		}
+	}
+}
+
+// Two letters that share a cell of a Playfair square, in place of I and
+// J. The second is read as the first wherever it appears, in either
+// case. An alphabet of 25 letters fills the square without merging any.
+func WithMergedLetters(keep rune, merge rune) Option {
+	return func(o *options) {
+		o.merged = [2]rune{keep, merge}
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		alphabet: lookup.Latin,
+		merged:   [2]rune{'I', 'J'},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Ranks the letters of `key` by their place in `alphabet`, as keyOrder
+// does by their place in A to Z. Anything outside the alphabet is
+// dropped.
+func alphabetKeyOrder(key string, alphabet *lookup.Alphabet) []int {
+	letters := []rune(alphabet.Filter(key))
+	sorted := make([]int, len(letters))
+	for i := range sorted {
+		sorted[i] = i
+	}
+	slices.SortStableFunc(sorted, func(a, b int) int {
+		return alphabet.Index(letters[a]) - alphabet.Index(letters[b])
+	})
+
+	order := make([]int, len(letters))
+	for rank, pos := range sorted {
+		order[pos] = rank
+	}
+	return order
+}
+
+// Letter that fills out the last block of a transposition: `X`, or the
+// alphabet's last letter when it has no X.
+func paddingLetter(alphabet *lookup.Alphabet) rune {
+	if x, ok := alphabet.Fold('X'); ok {
+		return x
+	}
+	return alphabet.At(alphabet.Len() - 1)
+}
@@ -0,0 +1,166 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"strings"
+	"testing"
+)
+
+type OptionsTest struct {
+	suite.Suite
+}
+
+func (suite *OptionsTest) TestDefault() {
+	// letters outside A to Z pass through, whatever their width
+	enc, err := NewCaesar(3).Encode("héllo")
+	suite.Nil(err)
+	suite.Equal("kéoor", enc)
+
+	enc, err = NewCaesar(3, WithAlphabet(nil)).Encode("héllo")
+	suite.Nil(err)
+	suite.Equal("kéoor", enc)
+}
+
+func (suite *OptionsTest) TestNational() {
+	greek := NewCaesar(3, WithAlphabet(lookup.Greek))
+	enc, err := greek.Encode("Αλφα βήτα!")
+	suite.Nil(err)
+	suite.Equal("Δξωδ εήχδ!", enc)
+	dec, err := greek.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("Αλφα βήτα!", dec)
+
+	// Hebrew has no case, and final forms pass through
+	enc, err = NewCaesar(1, WithAlphabet(lookup.Hebrew)).Encode("שלום")
+	suite.Nil(err)
+	suite.Equal("תמזם", enc)
+
+	russian := NewVigenere("КЛЮЧ", WithAlphabet(lookup.Cyrillic))
+	enc, err = russian.Encode("привет, мир")
+	suite.Nil(err)
+	suite.Equal('ъ', []rune(enc)[0])
+	dec, err = russian.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("привет, мир", dec)
+
+	enc, err = NewCaesar(5, WithAlphabet(lookup.LatinDigits)).Encode("zulu")
+	suite.Nil(err)
+	suite.Equal("4zqz", enc)
+}
+
+func (suite *OptionsTest) TestRoundTrips() {
+	greek := WithAlphabet(lookup.Greek)
+	plain := "Ο Σωκράτης είπε"
+	codecs := []struct {
+		Encoder
+		Decoder
+	}{
+		{NewVigenere("λόγος", greek), NewVigenere("λόγος", greek)},
+		{NewGronsfeld("314", greek), NewGronsfeld("314", greek)},
+		{NewTrithemius(2, greek), NewTrithemius(2, greek)},
+		{NewPorta("λόγος", greek), NewPorta("λόγος", greek)},
+		{NewProgressiveKey("λόγος", 2, greek), NewProgressiveKey("λόγος", 2, greek)},
+		{NewQuagmireIII("σοφια", "λογος", greek), NewQuagmireIII("σοφια", "λογος", greek)},
+	}
+
+	for _, c := range codecs {
+		enc, err := c.Encode(plain)
+		suite.Nil(err)
+		suite.NotEqual(plain, enc)
+		dec, err := c.Decode(enc)
+		suite.Nil(err)
+		suite.Equal(plain, dec)
+	}
+}
+
+func (suite *OptionsTest) TestTransposition() {
+	enc, err := NewScytale(2, 0, WithAlphabet(lookup.Greek)).Encode("αβ γδ")
+	suite.Nil(err)
+	suite.Equal("ΑΓΒΔ", enc)
+
+	nicodemus := NewNicodemus("ΚΛΕΙΔΙ", WithAlphabet(lookup.Greek))
+	enc, err = nicodemus.Encode("Ο Σωκράτης είπε")
+	suite.Nil(err)
+	dec, err := nicodemus.Decode(enc)
+	suite.Nil(err)
+	// accented letters and final sigma aren't in the alphabet
+	suite.Equal("ΟΣΩΚΡΤΗΕΠΕ", dec)
+
+	alberti := NewAlberti("ξψζ", 'α', 3, 1, true, WithAlphabet(lookup.Greek))
+	enc, err = alberti.Encode("Ο Σωκράτης")
+	suite.Nil(err)
+	dec, err = alberti.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("ΟΣΩΚΡΤΗ", dec)
+
+	// padding is Ω, as Greek has no X
+	greek := WithAlphabet(lookup.Greek)
+	plain := "ΓΝΩΘΙΣΕΑΥΤΟΝ"
+	padded := []struct {
+		Encoder
+		Decoder
+	}{
+		{NewColumnar("ΚΛΕΙΔΙ", 'Ω', greek), NewColumnar("ΚΛΕΙΔΙ", 'Ω', greek)},
+		{NewNihilistTransposition("ΓΑΒ", greek), NewNihilistTransposition("ΓΑΒ", greek)},
+		{NewSwagman([][]int{{1, 2}, {2, 1}}, greek), NewSwagman([][]int{{1, 2}, {2, 1}}, greek)},
+		{NewTurningGrille(2, [][2]int{{0, 0}}, greek), NewTurningGrille(2, [][2]int{{0, 0}}, greek)},
+		{NewCadenus("AB", greek), NewCadenus("AB", greek)},
+	}
+	for _, c := range padded {
+		enc, err := c.Encode(strings.ToLower(plain))
+		suite.Nil(err)
+		dec, err := c.Decode(enc)
+		suite.Nil(err)
+		suite.Equal(plain, strings.TrimRight(strings.ReplaceAll(dec, " ", ""), "Ω"))
+	}
+
+	// keys rank by their place in the alphabet, not their code points:
+	// Ё comes before А in Unicode
+	suite.Equal([]int{1, 2, 0}, alphabetKeyOrder("ёжа", lookup.Cyrillic))
+	enc, err = NewColumnar("ЁЖА", 0, WithAlphabet(lookup.Cyrillic)).Encode("абвгде")
+	suite.Nil(err)
+	suite.Equal("ВЕАГБД", enc)
+}
+
+func (suite *OptionsTest) TestKeyedAlphabets() {
+	greek := WithAlphabet(lookup.Greek)
+	plain := "ΓΝΩΘΙΣΕΑΥΤΟΝ"
+	codecs := []struct {
+		Encoder
+		Decoder
+	}{
+		{NewGromark("ΚΛΕΙΔΙ", "23452", greek), NewGromark("ΚΛΕΙΔΙ", "23452", greek)},
+		{NewPeriodicGromark("ΚΛΕΙΔΙ", greek), NewPeriodicGromark("ΚΛΕΙΔΙ", greek)},
+		{NewRagbaby("ΚΛΕΙΔΙ", greek), NewRagbaby("ΚΛΕΙΔΙ", greek)},
+	}
+	for _, c := range codecs {
+		enc, err := c.Encode(plain)
+		suite.Nil(err)
+		suite.NotEqual(plain, enc)
+		dec, err := c.Decode(enc)
+		suite.Nil(err)
+		suite.Equal(plain, dec)
+	}
+
+	// all 24 Greek letters are used, where A to Z folds J and X
+	suite.Len(string(gromarkAlphabet("ΚΛΕΙΔΙ", lookup.Greek)), len(lookup.Greek.String()))
+	mixed := WithAlphabet(lookup.NewKeyedAlphabet("ZEBRA", lookup.Latin))
+	enc, err := NewRagbaby("KEY", mixed).Encode("jax")
+	suite.Nil(err)
+	dec, err := NewRagbaby("KEY", mixed).Decode(enc)
+	suite.Nil(err)
+	suite.Equal("IAW", dec)
+}
+
+func (suite *OptionsTest) TestErrors() {
+	_, err := NewPorta("КЛЮЧ", WithAlphabet(lookup.Cyrillic)).Encode("привет")
+	suite.EqualError(err, "alphabet must have an even number of letters")
+
+	_, err = NewAlberti("", 'א', 0, 0, false, WithAlphabet(lookup.Hebrew)).Encode("שלום")
+	suite.EqualError(err, "alphabet must have upper and lower case")
+}
+
+func TestOptions(t *testing.T) {
+	suite.Run(t, new(OptionsTest))
+}
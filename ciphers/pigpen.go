@@ -0,0 +1,205 @@
+package ciphers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// https://en.wikipedia.org/wiki/Pigpen_cipher
+//
+// Letters are drawn as the part of a grid that surrounds them. A to I
+// sit in a noughts and crosses grid, J to R in a second grid with dots,
+// S to V in an X, and W to Z in a second X with dots.
+type Pigpen struct {
+	Encoder
+	Decoder
+}
+
+// Unicode approximations of the grid and X shapes, in letter order.
+var (
+	pigpenGridGlyphs = []rune("⌟⊔⌞⊐□⊏⌝⊓⌜")
+	pigpenXGlyphs    = []rune("∨><∧")
+)
+
+const pigpenDot = '·'
+
+// Size of each glyph's square in SVG output, and the space around the
+// lines drawn inside it.
+const (
+	pigpenCell   = 40
+	pigpenMargin = 8
+)
+
+func (p *Pigpen) glyph(c rune) string {
+	i := int(c - 'A')
+	var glyph string
+	if i < 18 {
+		glyph = string(pigpenGridGlyphs[i%9])
+	} else {
+		glyph = string(pigpenXGlyphs[(i-18)%4])
+	}
+	if i >= 9 && i < 18 || i >= 22 {
+		glyph += string(pigpenDot)
+	}
+	return glyph
+}
+
+// Encode writes each letter as a single shape, followed by `·` when the
+// letter comes from a dotted grid. Words are separated by spaces.
+func (p *Pigpen) Encode(s string) (string, error) {
+	words := []string{}
+	for _, word := range strings.Fields(s) {
+		var glyphs strings.Builder
+		for _, c := range prepareInput(word) {
+			glyphs.WriteString(p.glyph(c))
+		}
+		if glyphs.Len() > 0 {
+			words = append(words, glyphs.String())
+		}
+	}
+	return strings.Join(words, " "), nil
+}
+
+// Decode reads the Unicode shapes written by Encode, and also accepts
+// `.` for the dot.
+func (p *Pigpen) Decode(s string) (string, error) {
+	var decoded strings.Builder
+	runes := []rune(strings.TrimSpace(s))
+	space := false
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		if unicode.IsSpace(c) {
+			space = true
+			continue
+		}
+		if space {
+			decoded.WriteRune(' ')
+			space = false
+		}
+
+		dotted := i+1 < len(runes) && (runes[i+1] == pigpenDot || runes[i+1] == '.')
+		var letter rune
+		if g := indexRune(pigpenGridGlyphs, c); g > -1 {
+			letter = rune('A' + g)
+			if dotted {
+				letter += 9
+			}
+		} else if x := indexRune(pigpenXGlyphs, c); x > -1 {
+			letter = rune('S' + x)
+			if dotted {
+				letter += 4
+			}
+		} else {
+			return "", fmt.Errorf("unknown Pigpen glyph at %d: %s", i, string(c))
+		}
+
+		decoded.WriteRune(letter)
+		if dotted {
+			i++
+		}
+	}
+
+	return decoded.String(), nil
+}
+
+func indexRune(runes []rune, c rune) int {
+	for i, r := range runes {
+		if r == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// Path and dot position for a letter, drawn in a cell at x, y.
+func (p *Pigpen) shape(c rune, x, y int) (string, [2]int, bool) {
+	i := int(c - 'A')
+	x0, y0 := x+pigpenMargin, y+pigpenMargin
+	x1, y1 := x+pigpenCell-pigpenMargin, y+pigpenCell-pigpenMargin
+	xm, ym := x+pigpenCell/2, y+pigpenCell/2
+	dotted := i >= 9 && i < 18 || i >= 22
+
+	if i < 18 {
+		// the sides of a grid square that face another square
+		row, col := i%9/3, i%9%3
+		segments := []string{}
+		if row > 0 {
+			segments = append(segments, fmt.Sprintf("M%d %dH%d", x0, y0, x1))
+		}
+		if col < 2 {
+			segments = append(segments, fmt.Sprintf("M%d %dV%d", x1, y0, y1))
+		}
+		if row < 2 {
+			segments = append(segments, fmt.Sprintf("M%d %dH%d", x0, y1, x1))
+		}
+		if col > 0 {
+			segments = append(segments, fmt.Sprintf("M%d %dV%d", x0, y0, y1))
+		}
+		return strings.Join(segments, " "), [2]int{xm, ym}, dotted
+	}
+
+	third := (pigpenCell - 2*pigpenMargin) / 6
+	switch (i - 18) % 4 {
+	case 0:
+		return fmt.Sprintf("M%d %dL%d %dL%d %d", x0, y0, xm, y1, x1, y0), [2]int{xm, ym - third}, dotted
+	case 1:
+		return fmt.Sprintf("M%d %dL%d %dL%d %d", x0, y0, x1, ym, x0, y1), [2]int{xm - third, ym}, dotted
+	case 2:
+		return fmt.Sprintf("M%d %dL%d %dL%d %d", x1, y0, x0, ym, x1, y1), [2]int{xm + third, ym}, dotted
+	default:
+		return fmt.Sprintf("M%d %dL%d %dL%d %d", x0, y1, xm, y0, x1, y1), [2]int{xm, ym + third}, dotted
+	}
+}
+
+// Draws the message as an SVG image with up to `perLine` glyphs on each
+// line, leaving a gap between words and starting a new line rather than
+// splitting a word where it can.
+func (p *Pigpen) SVG(s string, perLine int) (string, error) {
+	if perLine < 1 {
+		return "", errors.New("expected positive number of glyphs per line")
+	}
+
+	var body strings.Builder
+	row, col, width := 0, 0, 0
+	for _, token := range strings.Fields(s) {
+		word := prepareInput(token)
+		if len(word) == 0 {
+			continue
+		}
+		if col > 0 && col+len(word) > perLine {
+			row, col = row+1, 0
+		}
+
+		for _, c := range word {
+			if col == perLine {
+				row, col = row+1, 0
+			}
+			path, dot, dotted := p.shape(c, col*pigpenCell, row*pigpenCell)
+			fmt.Fprintf(&body, "  <path d=\"%s\"/>\n", path)
+			if dotted {
+				fmt.Fprintf(&body, "  <circle cx=\"%d\" cy=\"%d\" r=\"3\" fill=\"black\"/>\n", dot[0], dot[1])
+			}
+			col++
+			width = max(width, col)
+		}
+		col++
+	}
+
+	w, h := width*pigpenCell, (row+1)*pigpenCell
+	if width == 0 {
+		h = 0
+	}
+	return fmt.Sprintf(
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n"+
+			"<g fill=\"none\" stroke=\"black\" stroke-width=\"3\" stroke-linecap=\"round\" stroke-linejoin=\"round\">\n"+
+			"%s</g>\n</svg>",
+		w, h, w, h, body.String(),
+	), nil
+}
+
+func NewPigpen() *Pigpen {
+	return &Pigpen{}
+}
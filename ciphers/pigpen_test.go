@@ -0,0 +1,68 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type PigpenTest struct {
+	suite.Suite
+	pigpen *Pigpen
+}
+
+func (suite *PigpenTest) SetupTest() {
+	suite.pigpen = NewPigpen()
+}
+
+func (suite *PigpenTest) TestEncoding() {
+	enc, err := suite.pigpen.Encode("Hello, world!")
+	suite.Nil(err)
+	suite.Equal("⊓□⌞·⌞·⊏· ∨·⊏·⌜·⌞·⊐", enc)
+
+	enc, err = suite.pigpen.Encode("stuv wxyz")
+	suite.Nil(err)
+	suite.Equal("∨><∧ ∨·>·<·∧·", enc)
+}
+
+func (suite *PigpenTest) TestDecoding() {
+	dec, err := suite.pigpen.Decode("⊓□⌞·⌞·⊏· ∨·⊏·⌜·⌞·⊐")
+	suite.Nil(err)
+	suite.Equal("HELLO WORLD", dec)
+
+	// plain full stops work as dots
+	dec, err = suite.pigpen.Decode("  ⌟⊔.⌞  ∧. ")
+	suite.Nil(err)
+	suite.Equal("AKC Z", dec)
+
+	alphabet := "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	enc, err := suite.pigpen.Encode(alphabet)
+	suite.Nil(err)
+	dec, err = suite.pigpen.Decode(enc)
+	suite.Nil(err)
+	suite.Equal(alphabet, dec)
+}
+
+func (suite *PigpenTest) TestSVG() {
+	svg, err := suite.pigpen.SVG("ab jk", 3)
+	suite.Nil(err)
+	suite.True(strings.HasPrefix(svg, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"80\" height=\"80\""))
+	suite.Equal(4, strings.Count(svg, "<path "))
+	suite.Equal(2, strings.Count(svg, "<circle "))
+	// A is the top left square of the grid, open at the top and left
+	suite.Contains(svg, "<path d=\"M32 8V32 M8 32H32\"/>")
+	// J wraps onto the second line
+	suite.Contains(svg, "<circle cx=\"20\" cy=\"60\"")
+
+	_, err = suite.pigpen.SVG("ab", 0)
+	suite.NotNil(err)
+}
+
+func (suite *PigpenTest) TestErrors() {
+	_, err := suite.pigpen.Decode("⊓□x")
+	suite.EqualError(err, "unknown Pigpen glyph at 2: x")
+}
+
+func TestPigpen(t *testing.T) {
+	suite.Run(t, new(PigpenTest))
+}
@@ -5,11 +5,11 @@ import (
 	"errors"
 	"fmt"
 	lookup "github.com/ubermensch/ciphers/lookup"
-	"regexp"
-	"slices"
+	ngram "github.com/ubermensch/ciphers/ngram"
+	"math"
+	"math/rand"
 	"strings"
 	"sync"
-	"unicode"
 )
 
 type gridFuncs struct {
@@ -50,6 +50,14 @@ type Playfair struct {
 	key string
 	// 5 x 5 cipher grid built from the key
 	grid [5][5]rune
+	// letters of the square, in upper case when they have case
+	alphabet *lookup.Alphabet
+	// the letter read as `keep`, as the two share a cell, or 0 if none
+	keep, merge rune
+	// letter splitting doubled letters and filling out the last digram
+	padding rune
+	// letters the key and alphabet gave, the square takes exactly 25
+	size int
 	// slice of 2-character digrams from the message to encrypt/decrypt
 	digrams [][]rune
 	Encoder
@@ -58,91 +66,53 @@ type Playfair struct {
 
 // Playfair doesn't handle non-letter chars and expects upper case.
 func prepareInput(input string) string {
-	rx := regexp.MustCompile(`[^a-zA-Z]`)
-	return strings.ToUpper(rx.ReplaceAllString(input, ``))
+	return lookup.Latin.Filter(input)
 }
 
-func gridFromKey(startKey string) [5][5]rune {
-	key := prepareInput(startKey)
-	var grid = [5][5]rune{}
-	var usedChars = []rune{}
-	fillerFrom := 0
-	filler := lookup.NewAlphaRing(false)
-	i, j := 0, 0
-
-	nextGridPos := func() {
-		if j == 4 {
-			j = 0
-			i++
-			return
+// The letters of `input` in the square's alphabet, with the merged
+// letter read as the one it shares a cell with.
+func (p *Playfair) prepare(input string) []rune {
+	letters := []rune(p.alphabet.Filter(input))
+	for i, c := range letters {
+		if c == p.merge {
+			letters[i] = p.keep
 		}
-		j++
-	}
-
-	nextFiller := func() rune {
-		next, err := filler.Move('A', fillerFrom)
-		if err != nil {
-			panic(err)
-		}
-		fillerFrom++
-		return rune(next)
 	}
+	return letters
+}
 
-	nextChar := func() rune {
-		var next rune
+// Fills the grid with the distinct letters of the key and then the rest
+// of the alphabet, leaving out the merged letter. Also returns how many
+// letters that gave, which only fill the grid when there are 25.
+func gridFromKey(key []rune, alphabet *lookup.Alphabet, merge rune) ([5][5]rune, int) {
+	var grid = [5][5]rune{}
+	used := map[rune]bool{merge: true}
+	size := 0
 
-		if len(key) > 0 {
-			next = rune(key[0])
-			key = key[1:]
-		} else {
-			next = nextFiller()
+	for _, next := range append(key, []rune(alphabet.String())...) {
+		if used[next] {
+			continue
 		}
-		return next
-	}
-
-	// Is the given rune present in usedChars, and is it one of the interchangeable
-	// chars ('I' or 'J')
-	isInterchangeable := func(c rune) bool {
-		return slices.ContainsFunc(usedChars, func(u rune) bool {
-			inter := []rune{'I', 'J'}
-			return slices.Contains(inter, c) && slices.Contains(inter, u)
-		})
-	}
-
-	for i < 5 {
-		// get next (from key if present, otherwise nextFiller())
-		next := nextChar()
-
-		// keep getting next while:
-		//    1) next is not a letter
-		//    2) next is present in usedChars
-		//    3) next is 'I' or 'J' and 'I' or 'J' present in usedChars
-		for !unicode.IsLetter(next) || slices.Contains(usedChars, next) || isInterchangeable(next) {
-			next = nextChar()
+		used[next] = true
+		if size < 25 {
+			grid[size/5][size%5] = next
 		}
-
-		grid[i][j] = next
-		usedChars = append(usedChars, next)
-
-		// move the grid pointers one space to right or to newline if filled
-		// current []rune{} slice
-		nextGridPos()
+		size++
 	}
 
-	return grid
+	return grid, size
 }
 
-func getDigrams(input string) [][]rune {
-	str := prepareInput(input)
+func getDigrams(str []rune, padding rune) [][]rune {
 	digrams := [][]rune{}
 
 	takeTwo := func() {
-		digrams = append(digrams, []rune{rune(str[0]), rune(str[1])})
+		digrams = append(digrams, []rune{str[0], str[1]})
 		str = str[2:]
 	}
 
 	takeOne := func() {
-		digrams = append(digrams, []rune{rune(str[0]), 'X'})
+		digrams = append(digrams, []rune{str[0], padding})
 		str = str[1:]
 	}
 
@@ -154,6 +124,7 @@ func getDigrams(input string) [][]rune {
 				takeTwo()
 			} else {
 				// ...if they are the same (e.g. double 'e' in 'tree'), pad with 'X'
+				// or the alphabet's padding letter
 				takeOne()
 			}
 		} else {
@@ -270,13 +241,23 @@ func (p *Playfair) decodeDigram(dg *[]rune) (*[]rune, error) {
 	return decodedDigram, nil
 }
 
-func (p *Playfair) Encode(input string) (string, error) {
+func (p *Playfair) validate() error {
 	if len(p.key) == 0 {
-		return "", errors.New("empty key")
+		return errors.New("empty key")
+	}
+	if p.size != 25 {
+		return fmt.Errorf("square needs 25 letters, key and alphabet give %d", p.size)
+	}
+	return nil
+}
+
+func (p *Playfair) Encode(input string) (string, error) {
+	if err := p.validate(); err != nil {
+		return "", err
 	}
 
 	// build digrams from input
-	p.digrams = getDigrams(input)
+	p.digrams = getDigrams(p.prepare(input), p.padding)
 
 	encodedDigrams := make([]string, len(p.digrams))
 	wg := sync.WaitGroup{}
@@ -307,12 +288,12 @@ func (p *Playfair) Encode(input string) (string, error) {
 }
 
 func (p *Playfair) Decode(input string) (string, error) {
-	if len(p.key) == 0 {
-		return "", errors.New("empty key")
+	if err := p.validate(); err != nil {
+		return "", err
 	}
 
 	// build digrams from input
-	p.digrams = getDigrams(input)
+	p.digrams = getDigrams(p.prepare(input), p.padding)
 
 	decodedDigrams := make([]string, len(p.digrams))
 	wg := sync.WaitGroup{}
@@ -342,12 +323,249 @@ func (p *Playfair) Decode(input string) (string, error) {
 	return strings.Join(decodedDigrams, " "), nil
 }
 
-func NewPlayfair(key string) *Playfair {
-	// build grid from key
-	grid := gridFromKey(key)
+// A key square found by SolvePlayfair.
+type PlayfairSolution struct {
+	Grid [5][5]rune
+	// the grid read row by row, ready for NewPlayfair
+	Key       string
+	Plaintext string
+	// higher is more like English
+	Score float64
+}
+
+// A key square as it's searched over: the 25 letters A to Z without J
+// as 0 to 25, row by row, and the cell each letter is in.
+type playfairSquare struct {
+	cells [25]int
+	pos   [26]int
+}
+
+func (sq *playfairSquare) index() {
+	for cell, letter := range sq.cells {
+		sq.pos[letter] = cell
+	}
+}
 
-	return &Playfair{
-		key:  key,
-		grid: grid,
+func (sq *playfairSquare) grid() [5][5]rune {
+	grid := [5][5]rune{}
+	for cell, letter := range sq.cells {
+		grid[cell/5][cell%5] = rune('A' + letter)
 	}
+	return grid
+}
+
+// Decodes the digrams of `cipher` into `plain` by the same rules as
+// Playfair.Decode: letters in a row move left, in a column move up, and
+// otherwise swap columns. No digram may be a doubled letter.
+func (sq *playfairSquare) decode(cipher []int, plain []int) {
+	for i := 0; i+1 < len(cipher); i += 2 {
+		a, b := sq.pos[cipher[i]], sq.pos[cipher[i+1]]
+		ra, ca, rb, cb := a/5, a%5, b/5, b%5
+		switch {
+		case ra == rb:
+			plain[i] = sq.cells[ra*5+(ca+4)%5]
+			plain[i+1] = sq.cells[rb*5+(cb+4)%5]
+		case ca == cb:
+			plain[i] = sq.cells[(ra+4)%5*5+ca]
+			plain[i+1] = sq.cells[(rb+4)%5*5+cb]
+		default:
+			plain[i] = sq.cells[ra*5+cb]
+			plain[i+1] = sq.cells[rb*5+ca]
+		}
+	}
+}
+
+// Changes the square at random, mostly by swapping two cells and now
+// and then by swapping rows or columns, flipping it over or transposing
+// it, which keep most of a nearly right square together.
+func (sq *playfairSquare) mutate(rng *rand.Rand) {
+	old := sq.cells
+	switch n := rng.Intn(50); {
+	case n == 0:
+		r1, r2 := rng.Intn(5), rng.Intn(5)
+		for c := 0; c < 5; c++ {
+			sq.cells[r1*5+c], sq.cells[r2*5+c] = old[r2*5+c], old[r1*5+c]
+		}
+	case n == 1:
+		c1, c2 := rng.Intn(5), rng.Intn(5)
+		for r := 0; r < 5; r++ {
+			sq.cells[r*5+c1], sq.cells[r*5+c2] = old[r*5+c2], old[r*5+c1]
+		}
+	case n == 2:
+		// upside down
+		for r := 0; r < 5; r++ {
+			copy(sq.cells[r*5:r*5+5], old[(4-r)*5:(4-r)*5+5])
+		}
+	case n == 3:
+		// left to right
+		for cell := range sq.cells {
+			sq.cells[cell] = old[cell/5*5+4-cell%5]
+		}
+	case n == 4:
+		// reversed, turning it half way round
+		for cell := range sq.cells {
+			sq.cells[cell] = old[24-cell]
+		}
+	case n == 5:
+		for cell := range sq.cells {
+			sq.cells[cell] = old[cell%5*5+cell/5]
+		}
+	default:
+		i, j := rng.Intn(25), rng.Intn(25)
+		sq.cells[i], sq.cells[j] = sq.cells[j], sq.cells[i]
+	}
+	sq.index()
+}
+
+// One annealing run from a random square, which is left holding the
+// best square found. `improved` is passed the best square so far after
+// the first temperature, and after any later one that improved on it.
+func annealPlayfair(
+	cipher []int,
+	sq *playfairSquare,
+	changes int,
+	rng *rand.Rand,
+	improved func(*playfairSquare, float64),
+) float64 {
+	letters := []int{}
+	for c := 0; c < 26; c++ {
+		if c != 'J'-'A' {
+			letters = append(letters, c)
+		}
+	}
+	for i, j := range rng.Perm(25) {
+		sq.cells[i] = letters[j]
+	}
+	sq.index()
+
+	quadgrams := ngram.EnglishQuadgrams()
+	plain := make([]int, len(cipher))
+	sq.decode(cipher, plain)
+	score := quadgrams.ScoreIndices(plain)
+	best, bestScore := *sq, score
+
+	// a change's effect on the score grows with the message, and so does
+	// the temperature, falling from 0.07 to 0.03 per letter
+	steps := 100
+	reported := math.Inf(-1)
+	for step := 0; step < steps; step++ {
+		temperature := float64(len(cipher)) * (0.07 - 0.04*float64(step)/float64(steps))
+		for i := 0; i < changes; i++ {
+			current := *sq
+			sq.mutate(rng)
+			sq.decode(cipher, plain)
+			candidate := quadgrams.ScoreIndices(plain)
+
+			if delta := candidate - score; delta >= 0 || rng.Float64() < math.Exp(delta/temperature) {
+				score = candidate
+				if score > bestScore {
+					best, bestScore = *sq, score
+				}
+			} else {
+				*sq = current
+			}
+		}
+		if bestScore > reported {
+			improved(&best, bestScore)
+			reported = bestScore
+		}
+	}
+
+	*sq = best
+	return bestScore
+}
+
+// Breaks a Playfair ciphertext by simulated annealing over key squares,
+// scoring each decoding against English quadgrams. Worse squares are
+// sometimes kept while the temperature is high, so the search can climb
+// out of dead ends, and less often as it cools. J is read as I, as the
+// square holds only one of them. Each restart anneals from a new random
+// square over 100 falling temperatures, trying `ChangesPerTemperature`
+// changes at each. Restarts default to 3 and changes to 5000, and
+// `Iterations` isn't used. Ciphertexts much shorter than 200 letters are
+// hard to break, and those with a digram of doubled letters, which
+// Playfair never gives, are refused.
+func SolvePlayfair(s string, settings SolverSettings) (*PlayfairSolution, error) {
+	settings = settings.withAnnealingDefaults(3, 5000)
+
+	str := strings.ReplaceAll(prepareInput(s), "J", "I")
+	if len(str)%2 != 0 {
+		return nil, errors.New("ciphertext must have an even number of letters")
+	}
+	if len(str) < 4 {
+		return nil, errors.New("not enough letters to solve")
+	}
+	for i := 0; i < len(str); i += 2 {
+		// Playfair splits doubled letters, so no square enciphers to them
+		if str[i] == str[i+1] {
+			return nil, fmt.Errorf("digram %d is a doubled letter: %s", i/2+1, str[i:i+2])
+		}
+	}
+	cipher := make([]int, len(str))
+	for i, c := range str {
+		cipher[i] = int(c - 'A')
+	}
+
+	rng := rand.New(rand.NewSource(settings.Seed))
+	var solution *PlayfairSolution
+	sq := &playfairSquare{}
+
+	for restart := 0; restart < settings.Restarts; restart++ {
+		improved := func(best *playfairSquare, score float64) {
+			if solution != nil && score <= solution.Score {
+				return
+			}
+			grid := best.grid()
+			key := []rune{}
+			for _, row := range grid {
+				key = append(key, row[:]...)
+			}
+			plaintext, err := NewPlayfair(string(key)).Decode(str)
+			if err != nil {
+				return
+			}
+
+			solution = &PlayfairSolution{
+				Grid:      grid,
+				Key:       string(key),
+				Plaintext: plaintext,
+				Score:     score,
+			}
+			settings.report(SolverProgress{
+				Restart:   restart,
+				Key:       solution.Key,
+				Plaintext: solution.Plaintext,
+				Score:     solution.Score,
+			})
+		}
+		annealPlayfair(cipher, sq, settings.ChangesPerTemperature, rng, improved)
+	}
+
+	return solution, nil
+}
+
+// Builds the square over A to Z with J read as I, unless given another
+// alphabet or merged pair. The square holds 25 letters, so the alphabet
+// must have 25, or 26 with two of them merged. Encode and Decode report
+// an error otherwise.
+func NewPlayfair(key string, opts ...Option) *Playfair {
+	o := newOptions(opts)
+	p := &Playfair{
+		key:      key,
+		alphabet: o.alphabet.Upper(),
+	}
+
+	keep, keepOk := p.alphabet.Fold(o.merged[0])
+	merge, mergeOk := p.alphabet.Fold(o.merged[1])
+	if p.alphabet.Len() > 25 && keepOk && mergeOk && keep != merge {
+		p.keep, p.merge = keep, merge
+	}
+	p.padding = paddingLetter(p.alphabet)
+	if p.padding == p.merge {
+		p.padding = p.keep
+	}
+
+	// build grid from key
+	p.grid, p.size = gridFromKey(p.prepare(key), p.alphabet, p.merge)
+	return p
 }
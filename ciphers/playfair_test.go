@@ -2,6 +2,9 @@ package ciphers
 
 import (
 	"github.com/stretchr/testify/suite"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -127,6 +130,36 @@ func (suite *PlayfairTest) TestDecode() {
 	}
 }
 
+func (suite *PlayfairTest) TestAlphabet() {
+	// J is read as I by default
+	pf := NewPlayfair("playfair example")
+	enc, err := pf.Encode("jump")
+	suite.Nil(err)
+	suite.Equal("RT IF", enc)
+
+	// J takes the cell K leaves
+	ck := NewPlayfair("playfair example", WithMergedLetters('c', 'k'))
+	suite.Equal([5]rune{'J', 'N', 'O', 'Q', 'S'}, ck.grid[3])
+	enc, err = ck.Encode("kick jump")
+	suite.Nil(err)
+	suite.Equal("BR GR BN ZR YI", enc)
+	dec, err := ck.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("CI CX CJ UM PX", dec)
+
+	// 25 letters fill the square without merging
+	noQ, err := lookup.NewAlphabet("ABCDEFGHIJKLMNOPRSTUVWXYZ")
+	suite.Nil(err)
+	pf = NewPlayfair("playfair example", WithAlphabet(noQ))
+	suite.Equal([5]rune{'J', 'K', 'N', 'O', 'S'}, pf.grid[3])
+	enc, err = pf.Encode("quick jump")
+	suite.Nil(err)
+	suite.Equal("TR KU KT IF", enc)
+
+	_, err = NewPlayfair("ΚΛΕΙΔΙ", WithAlphabet(lookup.Greek)).Encode("ΑΒΓ")
+	suite.Equal("square needs 25 letters, key and alphabet give 24", err.Error())
+}
+
 func (suite *PlayfairTest) TestErrors() {
 	// should error with empty string key
 	pf := NewPlayfair("")
@@ -135,6 +168,104 @@ func (suite *PlayfairTest) TestErrors() {
 	suite.Equal("empty key", err.Error())
 }
 
+func (suite *PlayfairTest) TestSolve() {
+	plain := kerckhoffs
+	pf := NewPlayfair("monarchy")
+	enc, err := pf.Encode(plain)
+	suite.Nil(err)
+	want, err := pf.Decode(enc)
+	suite.Nil(err)
+
+	reports := 0
+	solution, err := SolvePlayfair(enc, SolverSettings{
+		Seed:     3,
+		Restarts: 1,
+		Progress: func(progress SolverProgress) {
+			reports++
+		},
+	})
+	suite.Nil(err)
+	suite.Equal(want, solution.Plaintext)
+	suite.Greater(reports, 0)
+
+	// the square found is the key square, give or take its rows and
+	// columns being rotated, which enciphers the same
+	suite.True(sameUpToRotation(pf.grid, solution.Grid), "%q", solution.Key)
+	dec, err := NewPlayfair(solution.Key).Decode(enc)
+	suite.Nil(err)
+	suite.Equal(want, dec)
+}
+
+func (suite *PlayfairTest) TestSquareDecode() {
+	// the solver's decoding agrees with Playfair.Decode on random squares
+	rng := rand.New(rand.NewSource(1))
+	for try := 0; try < 20; try++ {
+		sq := &playfairSquare{}
+		for i, j := range rng.Perm(25) {
+			sq.cells[i] = int("ABCDEFGHIKLMNOPQRSTUVWXYZ"[j] - 'A')
+		}
+		sq.index()
+
+		cipher := make([]int, 100)
+		text := make([]rune, len(cipher))
+		for i := range cipher {
+			cipher[i] = sq.cells[rng.Intn(25)]
+			// Playfair never gives a doubled letter
+			for i%2 == 1 && cipher[i] == cipher[i-1] {
+				cipher[i] = sq.cells[rng.Intn(25)]
+			}
+			text[i] = rune('A' + cipher[i])
+		}
+
+		plain := make([]int, len(cipher))
+		sq.decode(cipher, plain)
+		want := make([]rune, len(plain))
+		for i, p := range plain {
+			want[i] = rune('A' + p)
+		}
+
+		grid := sq.grid()
+		key := []rune{}
+		for _, row := range grid {
+			key = append(key, row[:]...)
+		}
+		dec, err := NewPlayfair(string(key)).Decode(string(text))
+		suite.Nil(err)
+		suite.Equal(string(want), strings.ReplaceAll(dec, " ", ""))
+	}
+}
+
+// Whether `b` is `a` with its rows and columns cyclically shifted.
+func sameUpToRotation(a [5][5]rune, b [5][5]rune) bool {
+	for dr := 0; dr < 5; dr++ {
+		for dc := 0; dc < 5; dc++ {
+			same := true
+			for r := 0; r < 5 && same; r++ {
+				for c := 0; c < 5 && same; c++ {
+					same = a[(r+dr)%5][(c+dc)%5] == b[r][c]
+				}
+			}
+			if same {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (suite *PlayfairTest) TestSolveErrors() {
+	_, err := SolvePlayfair("ABC", SolverSettings{})
+	suite.Equal("ciphertext must have an even number of letters", err.Error())
+	_, err = SolvePlayfair("AB", SolverSettings{})
+	suite.Equal("not enough letters to solve", err.Error())
+
+	_, err = SolvePlayfair("XXXX", SolverSettings{})
+	suite.Equal("digram 1 is a doubled letter: XX", err.Error())
+	// J is read as I
+	_, err = SolvePlayfair("ABCD IJ", SolverSettings{})
+	suite.Equal("digram 3 is a doubled letter: II", err.Error())
+}
+
 func TestPlayfair(t *testing.T) {
 	suite.Run(t, new(PlayfairTest))
 }
@@ -0,0 +1,82 @@
+package ciphers
+
+import (
+	"errors"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"sync"
+	"sync/atomic"
+)
+
+// Tableau machinery shared by the polyalphabetic ciphers (Vigenère,
+// Gronsfeld, Trithemius, Porta...). Each letter is substituted within
+// its own case of the alphabet, using an alphabet row chosen by the
+// letter's position in the message. Anything that isn't a letter passes
+// through unchanged.
+type polyalphabetic struct {
+	alphabet *lookup.Alphabet
+}
+
+func newPolyalphabetic(alphabet *lookup.Alphabet) polyalphabetic {
+	return polyalphabetic{
+		alphabet: alphabet,
+	}
+}
+
+// Position of a key letter in the alphabet, ignoring case. Runes that
+// aren't letters give a shift of 0.
+func (p *polyalphabetic) offset(c rune) int {
+	if cased := p.alphabet.Cased(c); cased != nil {
+		return cased.Index(c)
+	}
+	return 0
+}
+
+// Moves c `offset` places around its case of the alphabet.
+func (p *polyalphabetic) shiftChar(c rune, offset int) (rune, error) {
+	if cased := p.alphabet.Cased(c); cased != nil {
+		return cased.Move(c, offset)
+	}
+	return c, nil
+}
+
+// Replaces c with the letter at the index returned by `substitute`,
+// for tableaux whose rows aren't plain shifts of the alphabet.
+func (p *polyalphabetic) substituteChar(c rune, substitute func(int) int) (rune, error) {
+	if cased := p.alphabet.Cased(c); cased != nil {
+		return cased.At(substitute(cased.Index(c))), nil
+	}
+	return c, nil
+}
+
+// Applies `substitute` to each rune of s in parallel, passing the rune's
+// position in the message so the caller can pick its key letter.
+func (p *polyalphabetic) transform(
+	s string,
+	substitute func(c rune, pos int) (rune, error),
+) (string, error) {
+	input := []rune(s)
+	runes := make([]rune, len(input))
+	wg := sync.WaitGroup{}
+	errCount := atomic.Int32{}
+
+	subFunc := func(r rune, pos int, wg *sync.WaitGroup) {
+		defer wg.Done()
+		sub, err := substitute(r, pos)
+		if err != nil {
+			errCount.Add(1)
+		}
+		runes[pos] = sub
+	}
+
+	for i, curr := range input {
+		wg.Add(1)
+		go subFunc(curr, i, &wg)
+	}
+
+	wg.Wait()
+	if errCount.Load() > 0 {
+		return "", errors.New("substitution failed")
+	}
+
+	return string(runes), nil
+}
@@ -0,0 +1,74 @@
+package ciphers
+
+import (
+	"errors"
+)
+
+// http://practicalcryptography.com/ciphers/porta-cipher/
+type Porta struct {
+	key string
+	polyalphabetic
+	Encoder
+	Decoder
+}
+
+// Each pair of key letters (AB, CD, ... YZ) selects one of 13 reciprocal
+// alphabets, which swap the first half of the alphabet with the second
+// half shifted along by the pair number. Since every alphabet is its own
+// inverse, encoding and decoding are the same operation. Other alphabets
+// work the same way, with half as many alphabets as letters.
+func (p *Porta) substitute(c rune, pos int) (rune, error) {
+	key := []rune(p.key)
+	half := p.alphabet.Len() / 2
+	pair := p.offset(key[pos%len(key)]) / 2
+
+	return p.substituteChar(c, func(idx int) int {
+		if idx < half {
+			return half + (idx+pair)%half
+		}
+		return (idx - half - pair + half) % half
+	})
+}
+
+func (p *Porta) validate() error {
+	if len(p.key) == 0 {
+		return errors.New("empty key")
+	}
+	if p.alphabet.Len()%2 != 0 {
+		return errors.New("alphabet must have an even number of letters")
+	}
+	return nil
+}
+
+func (p *Porta) Encode(s string) (string, error) {
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+
+	encoded, err := p.transform(s, p.substitute)
+	if err != nil {
+		return "", errors.New("encoding failed")
+	}
+
+	return encoded, nil
+}
+
+func (p *Porta) Decode(s string) (string, error) {
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+
+	decoded, err := p.transform(s, p.substitute)
+	if err != nil {
+		return "", errors.New("decoding failed")
+	}
+
+	return decoded, nil
+}
+
+func NewPorta(key string, opts ...Option) *Porta {
+	return &Porta{
+		key:            key,
+		polyalphabetic: newPolyalphabetic(newOptions(opts).alphabet),
+	}
+}
@@ -0,0 +1,74 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type portaCase struct {
+	key     string
+	plain   string
+	encoded string
+}
+
+type PortaTest struct {
+	suite.Suite
+	cases []*portaCase
+}
+
+func (suite *PortaTest) SetupTest() {
+	suite.cases = []*portaCase{
+		{
+			key:     "FORTIFICATION",
+			plain:   "DEFENDTHEEASTWALLOFTHECASTLE",
+			encoded: "SYNNJSCVRNRLAHUTUKUCVRYRLANY",
+		},
+		// 'a' and 'b' select the same alphabet, case is preserved
+		{
+			key:     "ab",
+			plain:   "Attack at dawn!",
+			encoded: "Nggnpx ng qnja!",
+		},
+	}
+}
+
+func (suite *PortaTest) TestEncoding() {
+	for _, cs := range suite.cases {
+		pt := NewPorta(cs.key)
+
+		enc, err := pt.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *PortaTest) TestDecoding() {
+	for _, cs := range suite.cases {
+		pt := NewPorta(cs.key)
+
+		dec, err := pt.Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.plain, dec)
+	}
+}
+
+func (suite *PortaTest) TestReciprocal() {
+	// encoding twice gives back the plaintext
+	pt := NewPorta("lemon")
+	enc, err := pt.Encode("attackatdawn")
+	suite.Nil(err)
+	twice, err := pt.Encode(enc)
+	suite.Nil(err)
+	suite.Equal("attackatdawn", twice)
+}
+
+func (suite *PortaTest) TestErrors() {
+	pt := NewPorta("")
+	_, err := pt.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("empty key", err.Error())
+}
+
+func TestPorta(t *testing.T) {
+	suite.Run(t, new(PortaTest))
+}
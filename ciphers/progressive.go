@@ -0,0 +1,61 @@
+package ciphers
+
+import (
+	"errors"
+)
+
+// https://www.cryptogram.org/resource-area/cipher-types/
+//
+// A Vigenère cipher whose key moves `progression` places further along
+// the alphabet each time it repeats, e.g. key `KEY` with progression 1
+// is used as `KEYLFZMGA...`.
+type ProgressiveKey struct {
+	key         string
+	progression int
+	polyalphabetic
+	Encoder
+	Decoder
+}
+
+func (p *ProgressiveKey) keyOffset(pos int) int {
+	key := []rune(p.key)
+	return p.offset(key[pos%len(key)]) + pos/len(key)*p.progression
+}
+
+func (p *ProgressiveKey) Encode(s string) (string, error) {
+	if len(p.key) == 0 {
+		return "", errors.New("empty key")
+	}
+
+	encoded, err := p.transform(s, func(c rune, pos int) (rune, error) {
+		return p.shiftChar(c, p.keyOffset(pos))
+	})
+	if err != nil {
+		return "", errors.New("encoding failed")
+	}
+
+	return encoded, nil
+}
+
+func (p *ProgressiveKey) Decode(s string) (string, error) {
+	if len(p.key) == 0 {
+		return "", errors.New("empty key")
+	}
+
+	decoded, err := p.transform(s, func(c rune, pos int) (rune, error) {
+		return p.shiftChar(c, -p.keyOffset(pos))
+	})
+	if err != nil {
+		return "", errors.New("decoding failed")
+	}
+
+	return decoded, nil
+}
+
+func NewProgressiveKey(key string, progression int, opts ...Option) *ProgressiveKey {
+	return &ProgressiveKey{
+		key:            key,
+		progression:    progression,
+		polyalphabetic: newPolyalphabetic(newOptions(opts).alphabet),
+	}
+}
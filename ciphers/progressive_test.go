@@ -0,0 +1,48 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ProgressiveKeyTest struct {
+	suite.Suite
+	progressive *ProgressiveKey
+}
+
+func (suite *ProgressiveKeyTest) SetupTest() {
+	suite.progressive = NewProgressiveKey("KEY", 1)
+}
+
+func (suite *ProgressiveKeyTest) TestEncoding() {
+	enc, err := suite.progressive.Encode("ATTACKATDAWN")
+	suite.Nil(err)
+	suite.Equal("KXRLHJMZDNDO", enc)
+
+	// a progression of 0 is plain Vigenère
+	enc, err = NewProgressiveKey("LEMON", 0).Encode("attackatdawn")
+	suite.Nil(err)
+	suite.Equal("lxfopvefrnhr", enc)
+}
+
+func (suite *ProgressiveKeyTest) TestDecoding() {
+	dec, err := suite.progressive.Decode("KXRLHJMZDNDO")
+	suite.Nil(err)
+	suite.Equal("ATTACKATDAWN", dec)
+
+	backwards := NewProgressiveKey("KEY", -3)
+	enc, err := backwards.Encode("Attack at dawn!")
+	suite.Nil(err)
+	dec, err = backwards.Decode(enc)
+	suite.Nil(err)
+	suite.Equal("Attack at dawn!", dec)
+}
+
+func (suite *ProgressiveKeyTest) TestErrors() {
+	_, err := NewProgressiveKey("", 1).Encode("ATTACKATDAWN")
+	suite.EqualError(err, "empty key")
+}
+
+func TestProgressiveKey(t *testing.T) {
+	suite.Run(t, new(ProgressiveKeyTest))
+}
@@ -0,0 +1,119 @@
+package ciphers
+
+import (
+	"errors"
+	lookup "github.com/ubermensch/ciphers/lookup"
+)
+
+// https://www.cryptogram.org/resource-area/cipher-types/
+//
+// The Quagmires are Vigenère ciphers with keyword-mixed alphabets. The
+// plaintext alphabet is written above a cipher alphabet which is slid
+// along so that each letter of the indicator key in turn sits under the
+// first letter of the plaintext alphabet.
+//
+//   - Quagmire I: keyed plaintext alphabet, straight cipher alphabet
+//   - Quagmire II: straight plaintext alphabet, keyed cipher alphabet
+//   - Quagmire III: both alphabets keyed with the same keyword
+//   - Quagmire IV: both alphabets keyed, each with its own keyword
+//
+// Kryptos K1 and K2 are Quagmire III with the keyword `KRYPTOS`.
+type Quagmire struct {
+	// key selecting the cipher alphabet for each position
+	indicator string
+	// plaintext alphabet
+	polyalphabetic
+	cipher *lookup.Alphabet
+	Encoder
+	Decoder
+}
+
+// How far the cipher alphabet is slid for the indicator letter at `pos`.
+func (q *Quagmire) keyOffset(pos int) int {
+	key := []rune(q.indicator)
+	return q.cipher.Index(key[pos%len(key)])
+}
+
+// The cipher alphabet in the same case as `plain`, one of the plaintext
+// alphabet's cases.
+func (q *Quagmire) cipherCase(plain *lookup.Alphabet) *lookup.Alphabet {
+	if plain == q.alphabet || q.cipher.Pair() == nil {
+		return q.cipher
+	}
+	return q.cipher.Pair()
+}
+
+func (q *Quagmire) encodeChar(c rune, pos int) (rune, error) {
+	plain := q.alphabet.Cased(c)
+	if plain == nil {
+		return c, nil
+	}
+	return q.cipherCase(plain).At(plain.Index(c) + q.keyOffset(pos)), nil
+}
+
+func (q *Quagmire) decodeChar(c rune, pos int) (rune, error) {
+	for _, plain := range []*lookup.Alphabet{q.alphabet, q.alphabet.Pair()} {
+		if plain == nil {
+			continue
+		}
+		if cipher := q.cipherCase(plain); cipher.Contains(c) {
+			return plain.At(cipher.Index(c) - q.keyOffset(pos)), nil
+		}
+	}
+	return c, nil
+}
+
+func (q *Quagmire) Encode(s string) (string, error) {
+	if len(q.indicator) == 0 {
+		return "", errors.New("empty key")
+	}
+
+	encoded, err := q.transform(s, q.encodeChar)
+	if err != nil {
+		return "", errors.New("encoding failed")
+	}
+
+	return encoded, nil
+}
+
+func (q *Quagmire) Decode(s string) (string, error) {
+	if len(q.indicator) == 0 {
+		return "", errors.New("empty key")
+	}
+
+	decoded, err := q.transform(s, q.decodeChar)
+	if err != nil {
+		return "", errors.New("decoding failed")
+	}
+
+	return decoded, nil
+}
+
+func newQuagmire(plainKey string, cipherKey string, indicator string, opts []Option) *Quagmire {
+	alphabet := newOptions(opts).alphabet
+	return &Quagmire{
+		indicator:      alphabet.Filter(indicator),
+		polyalphabetic: newPolyalphabetic(lookup.NewKeyedAlphabet(plainKey, alphabet)),
+		cipher:         lookup.NewKeyedAlphabet(cipherKey, alphabet),
+	}
+}
+
+// Keyed plaintext alphabet against a straight cipher alphabet.
+func NewQuagmireI(plainKey string, indicator string, opts ...Option) *Quagmire {
+	return newQuagmire(plainKey, "", indicator, opts)
+}
+
+// Straight plaintext alphabet against a keyed cipher alphabet.
+func NewQuagmireII(cipherKey string, indicator string, opts ...Option) *Quagmire {
+	return newQuagmire("", cipherKey, indicator, opts)
+}
+
+// Plaintext and cipher alphabets keyed with the same keyword.
+func NewQuagmireIII(alphabetKey string, indicator string, opts ...Option) *Quagmire {
+	return newQuagmire(alphabetKey, alphabetKey, indicator, opts)
+}
+
+// Plaintext and cipher alphabets keyed with different keywords.
+func NewQuagmireIV(plainKey string, cipherKey string, indicator string, opts ...Option) *Quagmire {
+	return newQuagmire(plainKey, cipherKey, indicator, opts)
+}
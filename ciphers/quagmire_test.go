@@ -0,0 +1,78 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type quagmireCase struct {
+	quagmire *Quagmire
+	plain    string
+	encoded  string
+}
+
+type QuagmireTest struct {
+	suite.Suite
+	cases []*quagmireCase
+}
+
+func (suite *QuagmireTest) SetupTest() {
+	suite.cases = []*quagmireCase{
+		// Kryptos K1
+		{
+			quagmire: NewQuagmireIII("kryptos", "palimpsest"),
+			plain:    "BETWEENSUBTLESHADINGANDTHEABSENCEOFLIGHTLIESTHENUANCEOFIQLUSION",
+			encoded:  "EMUFPHZLRFAXYUSDJKZLDKRNSHGNFIVJYQTQUXQBQVYUVLLTREVJYQTMKYRDMFD",
+		},
+		// start of Kryptos K2
+		{
+			quagmire: NewQuagmireIII("kryptos", "abscissa"),
+			plain:    "ITWASTOTALLYINVISIBLEHOWSTHATPOSSIBLE",
+			encoded:  "VFPJUDEEHZWETZYVGWHKKQETGFQJNCEGGWHKK",
+		},
+		// plaintext `S` is the first letter of the keyed alphabet, so it
+		// takes the indicator letter; `A` is six letters further on
+		{
+			quagmire: NewQuagmireI("spring", "a"),
+			plain:    "Sa sa",
+			encoded:  "Ag ag",
+		},
+		{
+			quagmire: NewQuagmireII("spring", "sp"),
+			plain:    "AAAA",
+			encoded:  "SPSP",
+		},
+		{
+			quagmire: NewQuagmireIV("spring", "flower", "fl"),
+			plain:    "SSpp",
+			encoded:  "FLlo",
+		},
+	}
+}
+
+func (suite *QuagmireTest) TestEncoding() {
+	for _, cs := range suite.cases {
+		enc, err := cs.quagmire.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *QuagmireTest) TestDecoding() {
+	for _, cs := range suite.cases {
+		dec, err := cs.quagmire.Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.plain, dec)
+	}
+}
+
+func (suite *QuagmireTest) TestErrors() {
+	qm := NewQuagmireIII("kryptos", "")
+	_, err := qm.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("empty key", err.Error())
+}
+
+func TestQuagmire(t *testing.T) {
+	suite.Run(t, new(QuagmireTest))
+}
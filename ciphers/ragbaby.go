@@ -0,0 +1,117 @@
+package ciphers
+
+import (
+	"errors"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// https://www.cryptogram.org/resource-area/cipher-types/
+//
+// Letters move along a keyed 24 letter alphabet, with I standing for J
+// and W for X. The first letter of the first word moves one place, and
+// each following letter one place more. Each word starts one place
+// further on than the word before it. Word breaks and punctuation are
+// kept as they are. Over an alphabet other than A to Z, every letter of
+// it is used.
+type Ragbaby struct {
+	key string
+	// the straight alphabet, which the key mixes
+	alphabet *lookup.Alphabet
+	// the alphabet is A to Z, in some order, so J and X are folded
+	latin bool
+	Encoder
+	Decoder
+}
+
+// The letter `c` stands as in the keyed alphabet: folded into the
+// alphabet's case and, for A to Z, with J as I and X as W. Reports false
+// for anything that isn't a letter of the alphabet.
+func (r *Ragbaby) letter(c rune) (rune, bool) {
+	c, ok := r.alphabet.Fold(c)
+	if !ok || !r.latin {
+		return c, ok
+	}
+	switch c {
+	case 'J':
+		return 'I', true
+	case 'X':
+		return 'W', true
+	}
+	return c, true
+}
+
+// The distinct key letters, then the rest of the alphabet, without J
+// or X for A to Z.
+func (r *Ragbaby) keyed() ([]rune, error) {
+	if len(r.alphabet.Filter(r.key)) == 0 {
+		return nil, errors.New("empty key")
+	}
+
+	letters := []rune{}
+	for _, c := range r.key + r.alphabet.String() {
+		if c, ok := r.letter(c); ok && !slices.Contains(letters, c) {
+			letters = append(letters, c)
+		}
+	}
+	return letters, nil
+}
+
+func (r *Ragbaby) shift(s string, direction int) (string, error) {
+	alphabet, err := r.keyed()
+	if err != nil {
+		return "", err
+	}
+
+	size := len(alphabet)
+	var shifted strings.Builder
+	word, offset := 0, 0
+	inWord := false
+
+	for _, c := range s {
+		if unicode.IsSpace(c) {
+			inWord = false
+			shifted.WriteRune(c)
+			continue
+		}
+		if !inWord {
+			inWord = true
+			word++
+			offset = word
+		}
+
+		letter, ok := r.letter(c)
+		if !ok {
+			shifted.WriteRune(c)
+			continue
+		}
+		i := slices.Index(alphabet, letter)
+		shifted.WriteRune(alphabet[((i+direction*offset)%size+size)%size])
+		offset++
+	}
+
+	return shifted.String(), nil
+}
+
+// Encode returns letters in the alphabet's case, with J written as I
+// and X as W.
+func (r *Ragbaby) Encode(s string) (string, error) {
+	return r.shift(s, 1)
+}
+
+func (r *Ragbaby) Decode(s string) (string, error) {
+	return r.shift(s, -1)
+}
+
+func NewRagbaby(key string, opts ...Option) *Ragbaby {
+	alphabet := newOptions(opts).alphabet
+	letters := []rune(alphabet.Upper().String())
+	slices.Sort(letters)
+	return &Ragbaby{
+		key:      key,
+		alphabet: alphabet,
+		latin:    string(letters) == lookup.Latin.String(),
+	}
+}
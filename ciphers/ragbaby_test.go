@@ -0,0 +1,45 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type RagbabyTest struct {
+	suite.Suite
+	ragbaby *Ragbaby
+}
+
+func (suite *RagbabyTest) SetupTest() {
+	suite.ragbaby = NewRagbaby("GROSBEAK")
+}
+
+func (suite *RagbabyTest) TestEncoding() {
+	enc, err := suite.ragbaby.Encode("Word divisions are kept.")
+	suite.Nil(err)
+	suite.Equal("YBBL HNGQDUFGL DEF HFYR.", enc)
+
+	// J and X are written as I and W
+	enc, err = suite.ragbaby.Encode("jinx")
+	suite.Nil(err)
+	suite.Equal("LMTR", enc)
+}
+
+func (suite *RagbabyTest) TestDecoding() {
+	dec, err := suite.ragbaby.Decode("YBBL HNGQDUFGL DEF HFYR.")
+	suite.Nil(err)
+	suite.Equal("WORD DIVISIONS ARE KEPT.", dec)
+
+	dec, err = suite.ragbaby.Decode("LMTR")
+	suite.Nil(err)
+	suite.Equal("IINW", dec)
+}
+
+func (suite *RagbabyTest) TestErrors() {
+	_, err := NewRagbaby("123").Encode("word")
+	suite.EqualError(err, "empty key")
+}
+
+func TestRagbaby(t *testing.T) {
+	suite.Run(t, new(RagbabyTest))
+}
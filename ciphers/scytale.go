@@ -0,0 +1,128 @@
+package ciphers
+
+import (
+	"cmp"
+	"errors"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	ngram "github.com/ubermensch/ciphers/ngram"
+	"math"
+	"slices"
+)
+
+// https://en.wikipedia.org/wiki/Scytale
+//
+// A strip wound around a rod is written on along the length of the
+// rod, and unwound to read. Each turn of the strip takes `circumference`
+// letters, one from each row written along the rod.
+type Scytale struct {
+	circumference int
+	// written into empty places on the last turns, or 0 to leave them
+	// out
+	padding rune
+	// letters kept from the message
+	alphabet *lookup.Alphabet
+	Encoder
+	Decoder
+}
+
+// A possible decoding found by BruteForceScytale.
+type ScytaleCandidate struct {
+	Circumference int
+	Plaintext     string
+	// higher is more like English
+	Score float64
+}
+
+// Message positions in the order they come off the strip, for a message
+// of `length` letters. The message fills rows along the rod, and the
+// strip reads down each turn, skipping any places past the end.
+func (s *Scytale) readingOrder(length int) []int {
+	rowLength := (length + s.circumference - 1) / s.circumference
+	order := make([]int, 0, length)
+
+	for col := 0; col < rowLength; col++ {
+		for row := 0; row < s.circumference; row++ {
+			if pos := row*rowLength + col; pos < length {
+				order = append(order, pos)
+			}
+		}
+	}
+	return order
+}
+
+// Encode pads the message to whole turns when padding is set.
+func (s *Scytale) Encode(str string) (string, error) {
+	if s.circumference < 1 {
+		return "", errors.New("expected positive circumference")
+	}
+
+	plain := []rune(s.alphabet.Filter(str))
+	if s.padding != 0 {
+		for len(plain)%s.circumference != 0 {
+			plain = append(plain, s.padding)
+		}
+	}
+
+	encoded := make([]rune, 0, len(plain))
+	for _, pos := range s.readingOrder(len(plain)) {
+		encoded = append(encoded, plain[pos])
+	}
+	return string(encoded), nil
+}
+
+// Decode leaves any padding in place.
+func (s *Scytale) Decode(str string) (string, error) {
+	if s.circumference < 1 {
+		return "", errors.New("expected positive circumference")
+	}
+
+	cipher := []rune(s.alphabet.Filter(str))
+	decoded := make([]rune, len(cipher))
+	for i, pos := range s.readingOrder(len(cipher)) {
+		decoded[pos] = cipher[i]
+	}
+	return string(decoded), nil
+}
+
+// Scores text as the mean log probability of its letter pairs in
+// English.
+func englishBigramScore(s string) float64 {
+	str := prepareInput(s)
+	if len(str) < 2 {
+		return math.Inf(-1)
+	}
+	return ngram.EnglishBigrams().Score(str) / float64(len(str)-1)
+}
+
+// Decodes `s` with every circumference from 2 up to one less than its
+// length, and returns the results best first. Padded messages are a
+// whole number of turns long, so they decode the same way. Scores only
+// mean anything for English, but other alphabets are still decoded.
+func BruteForceScytale(s string, opts ...Option) []ScytaleCandidate {
+	length := len([]rune(newOptions(opts).alphabet.Filter(s)))
+	candidates := []ScytaleCandidate{}
+
+	for c := 2; c < length; c++ {
+		// Decode can't fail with a positive circumference
+		plain, _ := NewScytale(c, 0, opts...).Decode(s)
+		candidates = append(candidates, ScytaleCandidate{
+			Circumference: c,
+			Plaintext:     plain,
+			Score:         englishBigramScore(plain),
+		})
+	}
+
+	slices.SortStableFunc(candidates, func(a, b ScytaleCandidate) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return candidates
+}
+
+// `padding` of 0 leaves the last turns short.
+func NewScytale(circumference int, padding rune, opts ...Option) *Scytale {
+	return &Scytale{
+		circumference: circumference,
+		padding:       padding,
+		alphabet:      newOptions(opts).alphabet,
+	}
+}
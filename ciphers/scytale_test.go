@@ -0,0 +1,86 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type scytaleCase struct {
+	scytale *Scytale
+	plain   string
+	encoded string
+	decoded string
+}
+
+type ScytaleTest struct {
+	suite.Suite
+	cases []*scytaleCase
+}
+
+func (suite *ScytaleTest) SetupTest() {
+	suite.cases = []*scytaleCase{
+		{
+			scytale: NewScytale(3, 0),
+			plain:   "Attack at dawn",
+			encoded: "ACDTKATAWATN",
+			decoded: "ATTACKATDAWN",
+		},
+		{
+			scytale: NewScytale(3, 'X'),
+			plain:   "Hello world",
+			encoded: "HOLEWDLOXLRX",
+			decoded: "HELLOWORLDXX",
+		},
+		// without padding the last turn is short
+		{
+			scytale: NewScytale(3, 0),
+			plain:   "Hello world",
+			encoded: "HOLEWDLOLR",
+			decoded: "HELLOWORLD",
+		},
+	}
+}
+
+func (suite *ScytaleTest) TestEncoding() {
+	for _, cs := range suite.cases {
+		enc, err := cs.scytale.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *ScytaleTest) TestDecoding() {
+	for _, cs := range suite.cases {
+		dec, err := cs.scytale.Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.decoded, dec)
+	}
+}
+
+func (suite *ScytaleTest) TestBruteForce() {
+	plain := "I am hurt very badly come to me faster"
+	for _, padding := range []rune{0, 'X'} {
+		enc, err := NewScytale(5, padding).Encode(plain)
+		suite.Nil(err)
+
+		candidates := BruteForceScytale(enc)
+		suite.Len(candidates, len(enc)-2)
+		suite.Equal(5, candidates[0].Circumference)
+		suite.Contains(candidates[0].Plaintext, prepareInput(plain))
+		suite.Greater(candidates[0].Score, candidates[1].Score)
+	}
+
+	suite.Empty(BruteForceScytale("ab"))
+}
+
+func (suite *ScytaleTest) TestErrors() {
+	_, err := NewScytale(0, 0).Encode("text")
+	suite.EqualError(err, "expected positive circumference")
+
+	_, err = NewScytale(-1, 0).Decode("text")
+	suite.EqualError(err, "expected positive circumference")
+}
+
+func TestScytale(t *testing.T) {
+	suite.Run(t, new(ScytaleTest))
+}
@@ -0,0 +1,128 @@
+package ciphers
+
+import (
+	"fmt"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// https://en.wikipedia.org/wiki/Flag_semaphore
+//
+// Each letter is written as the positions of the two flags, e.g. `S-SW`
+// for A. Digits follow the numerals sign and use the letters A to K.
+type Semaphore struct {
+	// flag positions to letter
+	decodeTable map[[2]string]rune
+	Encoder
+	Decoder
+}
+
+func semaphoreToken(positions [2]string) string {
+	return positions[0] + "-" + positions[1]
+}
+
+// Encode separates letters with spaces and words with ` / `. Anything
+// other than letters and digits is dropped.
+func (s *Semaphore) Encode(str string) (string, error) {
+	words := strings.Fields(strings.ToUpper(str))
+	encodedWords := []string{}
+	numerals := false
+
+	for _, word := range words {
+		tokens := []string{}
+		for _, c := range word {
+			if letter, ok := lookup.SemaphoreDigits[c]; ok {
+				if !numerals {
+					tokens = append(tokens, semaphoreToken(lookup.SemaphoreNumerals))
+					numerals = true
+				}
+				tokens = append(tokens, semaphoreToken(lookup.SemaphorePositions[letter]))
+				continue
+			}
+
+			positions, ok := lookup.SemaphorePositions[c]
+			if !ok {
+				continue
+			}
+			if numerals {
+				tokens = append(tokens, semaphoreToken(lookup.SemaphorePositions['J']))
+				numerals = false
+			}
+			tokens = append(tokens, semaphoreToken(positions))
+		}
+		if len(tokens) > 0 {
+			encodedWords = append(encodedWords, strings.Join(tokens, " "))
+		}
+	}
+
+	return strings.Join(encodedWords, " / "), nil
+}
+
+// Decode accepts the two positions of each letter in either order and
+// in any case, and takes `/` as a word break.
+func (s *Semaphore) Decode(str string) (string, error) {
+	var decoded strings.Builder
+	numerals := false
+
+	for i, token := range strings.Fields(strings.ToUpper(str)) {
+		if token == "/" {
+			decoded.WriteRune(' ')
+			continue
+		}
+
+		parts := strings.Split(token, "-")
+		if len(parts) != 2 {
+			return "", fmt.Errorf("expected two flag positions at %d: %s", i, token)
+		}
+		positions := [2]string{parts[0], parts[1]}
+		if slices.Index(lookup.SemaphoreDirections, positions[0]) >
+			slices.Index(lookup.SemaphoreDirections, positions[1]) {
+			positions[0], positions[1] = positions[1], positions[0]
+		}
+
+		if positions == lookup.SemaphoreNumerals {
+			numerals = true
+			continue
+		}
+		c, ok := s.decodeTable[positions]
+		if !ok {
+			return "", fmt.Errorf("unknown flag positions at %d: %s", i, token)
+		}
+
+		if numerals {
+			if c == 'J' {
+				numerals = false
+				continue
+			}
+			if digit, ok := s.digit(c); ok {
+				decoded.WriteRune(digit)
+				continue
+			}
+		}
+		decoded.WriteRune(c)
+	}
+
+	return strings.TrimFunc(decoded.String(), unicode.IsSpace), nil
+}
+
+func (s *Semaphore) digit(c rune) (rune, bool) {
+	for digit, letter := range lookup.SemaphoreDigits {
+		if letter == c {
+			return digit, true
+		}
+	}
+	return 0, false
+}
+
+func NewSemaphore() *Semaphore {
+	table := map[[2]string]rune{}
+	for c, positions := range lookup.SemaphorePositions {
+		table[positions] = c
+	}
+
+	return &Semaphore{
+		decodeTable: table,
+	}
+}
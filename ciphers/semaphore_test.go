@@ -0,0 +1,48 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SemaphoreTest struct {
+	suite.Suite
+	semaphore *Semaphore
+}
+
+func (suite *SemaphoreTest) SetupTest() {
+	suite.semaphore = NewSemaphore()
+}
+
+func (suite *SemaphoreTest) TestEncoding() {
+	enc, err := suite.semaphore.Encode("Go 2 bed!")
+	suite.Nil(err)
+	suite.Equal("S-SE W-NW / N-NE S-W / N-E S-W S-NE S-N", enc)
+
+	enc, err = suite.semaphore.Encode("Jazz 1920")
+	suite.Nil(err)
+	suite.Equal("N-E S-SW E-SE E-SE / N-NE S-SW SW-NW S-W SW-N", enc)
+}
+
+func (suite *SemaphoreTest) TestDecoding() {
+	dec, err := suite.semaphore.Decode("S-SE W-NW / N-NE S-W / N-E S-W S-NE S-N")
+	suite.Nil(err)
+	suite.Equal("GO 2 BED", dec)
+
+	// either flag can be given first
+	dec, err = suite.semaphore.Decode("sw-s se-e")
+	suite.Nil(err)
+	suite.Equal("AZ", dec)
+}
+
+func (suite *SemaphoreTest) TestErrors() {
+	_, err := suite.semaphore.Decode("S-SW S")
+	suite.EqualError(err, "expected two flag positions at 1: S")
+
+	_, err = suite.semaphore.Decode("S-S")
+	suite.EqualError(err, "unknown flag positions at 0: S-S")
+}
+
+func TestSemaphore(t *testing.T) {
+	suite.Run(t, new(SemaphoreTest))
+}
@@ -0,0 +1,54 @@
+package ciphers
+
+// How long the key searching solvers keep looking.
+type SolverSettings struct {
+	// separate searches from fresh random keys, the best of which wins
+	Restarts int
+	// how many changes to the key may fail in a row before a hill climb
+	// gives up
+	Iterations int
+	// how many changes to the key an annealing search tries at each
+	// temperature, where Iterations isn't used
+	ChangesPerTemperature int
+	// seeds the random keys and changes, so runs can be repeated
+	Seed int64
+	// called whenever a better key than any so far is found
+	Progress func(SolverProgress)
+}
+
+// The best key found so far, as passed to SolverSettings.Progress.
+type SolverProgress struct {
+	Restart   int
+	Key       string
+	Plaintext string
+	// higher is more like English
+	Score float64
+}
+
+// Fills in defaults for settings left at zero.
+func (s SolverSettings) withDefaults(restarts int, iterations int) SolverSettings {
+	if s.Restarts < 1 {
+		s.Restarts = restarts
+	}
+	if s.Iterations < 1 {
+		s.Iterations = iterations
+	}
+	return s
+}
+
+// As withDefaults, for annealing solvers.
+func (s SolverSettings) withAnnealingDefaults(restarts int, changes int) SolverSettings {
+	if s.Restarts < 1 {
+		s.Restarts = restarts
+	}
+	if s.ChangesPerTemperature < 1 {
+		s.ChangesPerTemperature = changes
+	}
+	return s
+}
+
+func (s SolverSettings) report(progress SolverProgress) {
+	if s.Progress != nil {
+		s.Progress(progress)
+	}
+}
@@ -0,0 +1,9 @@
+package ciphers
+
+// Kerckhoffs' principle and Shannon's maxim, English long enough for the
+// solvers to break, shared by their tests.
+const kerckhoffs = "A cryptosystem should be secure even if everything about the system, " +
+	"except the key, is public knowledge. This principle was stated by Auguste " +
+	"Kerckhoffs in the nineteenth century, and it remains one of the foundations " +
+	"of modern cryptography. Shannon later put it more bluntly: the enemy knows " +
+	"the system, and the security must rest on the key alone."
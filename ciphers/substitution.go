@@ -0,0 +1,182 @@
+package ciphers
+
+import (
+	"errors"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	ngram "github.com/ubermensch/ciphers/ngram"
+	"math/rand"
+)
+
+// https://en.wikipedia.org/wiki/Substitution_cipher#Simple_substitution
+//
+// Each letter is always replaced by the same letter of a mixed cipher
+// alphabet. The ACA's Aristocrats are simple substitutions that keep the
+// word breaks, and Patristocrats ones that don't.
+type Substitution struct {
+	// plaintext alphabet
+	polyalphabetic
+	cipher *lookup.Alphabet
+	Encoder
+	Decoder
+}
+
+// A key found by SolveSubstitution.
+type SubstitutionSolution struct {
+	// cipher alphabet, ready for NewSubstitution
+	Key       string
+	Plaintext string
+	// higher is more like English
+	Score float64
+}
+
+// The cipher alphabet in the same case as `plain`, one of the plaintext
+// alphabet's cases.
+func (sub *Substitution) cipherCase(plain *lookup.Alphabet) *lookup.Alphabet {
+	if plain == sub.alphabet || sub.cipher.Pair() == nil {
+		return sub.cipher
+	}
+	return sub.cipher.Pair()
+}
+
+func (sub *Substitution) encodeChar(c rune, pos int) (rune, error) {
+	plain := sub.alphabet.Cased(c)
+	if plain == nil {
+		return c, nil
+	}
+	return sub.cipherCase(plain).At(plain.Index(c)), nil
+}
+
+func (sub *Substitution) decodeChar(c rune, pos int) (rune, error) {
+	for _, plain := range []*lookup.Alphabet{sub.alphabet, sub.alphabet.Pair()} {
+		if plain == nil {
+			continue
+		}
+		if cipher := sub.cipherCase(plain); cipher.Contains(c) {
+			return plain.At(cipher.Index(c)), nil
+		}
+	}
+	return c, nil
+}
+
+// Encode keeps case, spaces and punctuation.
+func (sub *Substitution) Encode(s string) (string, error) {
+	encoded, err := sub.transform(s, sub.encodeChar)
+	if err != nil {
+		return "", errors.New("encoding failed")
+	}
+
+	return encoded, nil
+}
+
+func (sub *Substitution) Decode(s string) (string, error) {
+	decoded, err := sub.transform(s, sub.decodeChar)
+	if err != nil {
+		return "", errors.New("decoding failed")
+	}
+
+	return decoded, nil
+}
+
+// One hill climb from a random key. `key` maps each cipher letter to its
+// plaintext letter, 0 to 25 for A to Z, and is left holding the best key
+// found.
+func climbSubstitution(cipher []int, key []int, iterations int, rng *rand.Rand) float64 {
+	quadgrams := ngram.EnglishQuadgrams()
+	plain := make([]int, len(cipher))
+	decode := func() float64 {
+		for i, c := range cipher {
+			plain[i] = key[c]
+		}
+		return quadgrams.ScoreIndices(plain)
+	}
+
+	rng.Shuffle(len(key), func(i, j int) {
+		key[i], key[j] = key[j], key[i]
+	})
+	best := decode()
+
+	for failed := 0; failed < iterations; {
+		i, j := rng.Intn(len(key)), rng.Intn(len(key))
+		if i == j {
+			continue
+		}
+
+		key[i], key[j] = key[j], key[i]
+		if score := decode(); score > best {
+			best = score
+			failed = 0
+		} else {
+			key[i], key[j] = key[j], key[i]
+			failed++
+		}
+	}
+	return best
+}
+
+// Breaks a simple substitution over A to Z by hill climbing: starting
+// from a random key, pairs of letters are swapped whenever that makes
+// the decoding score better against English quadgrams. Each restart
+// climbs from a new random key until `Iterations` swaps in a row fail to
+// improve it, and the best result is kept. Word breaks and punctuation
+// are left in place but not used. Restarts default to 50 and iterations
+// to 2000. A couple of hundred letters are usually enough.
+func SolveSubstitution(s string, settings SolverSettings) (*SubstitutionSolution, error) {
+	settings = settings.withDefaults(50, 2000)
+
+	cipher := []int{}
+	for _, c := range prepareInput(s) {
+		cipher = append(cipher, int(c-'A'))
+	}
+	if len(cipher) < 4 {
+		return nil, errors.New("not enough letters to solve")
+	}
+
+	rng := rand.New(rand.NewSource(settings.Seed))
+	key := make([]int, 26)
+	for i := range key {
+		key[i] = i
+	}
+
+	var solution *SubstitutionSolution
+	for restart := 0; restart < settings.Restarts; restart++ {
+		score := climbSubstitution(cipher, key, settings.Iterations, rng)
+		if solution != nil && score <= solution.Score {
+			continue
+		}
+
+		// the key found decodes, so the cipher alphabet is its inverse
+		alphabet := make([]rune, 26)
+		for c, p := range key {
+			alphabet[p] = rune('A' + c)
+		}
+		sub := NewSubstitution(string(alphabet))
+		plaintext, err := sub.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+
+		solution = &SubstitutionSolution{
+			Key:       string(alphabet),
+			Plaintext: plaintext,
+			Score:     score,
+		}
+		settings.report(SolverProgress{
+			Restart:   restart,
+			Key:       solution.Key,
+			Plaintext: solution.Plaintext,
+			Score:     solution.Score,
+		})
+	}
+
+	return solution, nil
+}
+
+// `key` is a keyword for mixing the cipher alphabet, or the whole cipher
+// alphabet in order.
+func NewSubstitution(key string, opts ...Option) *Substitution {
+	alphabet := newOptions(opts).alphabet
+	return &Substitution{
+		polyalphabetic: newPolyalphabetic(alphabet),
+		cipher:         lookup.NewKeyedAlphabet(key, alphabet),
+	}
+}
@@ -0,0 +1,86 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type substitutionCase struct {
+	key     string
+	plain   string
+	encoded string
+}
+
+type SubstitutionTest struct {
+	suite.Suite
+	cases []*substitutionCase
+}
+
+func (suite *SubstitutionTest) SetupTest() {
+	suite.cases = []*substitutionCase{
+		{
+			key:     "ZEBRAS",
+			plain:   "Flee at once. We are discovered!",
+			encoded: "Siaa zq lkba. Va zoa rfpbluaoar!",
+		},
+		// a whole cipher alphabet works as the key
+		{
+			key:     "QWERTYUIOPASDFGHJKLZXCVBNM",
+			plain:   "HELLO WORLD",
+			encoded: "ITSSG VGKSR",
+		},
+	}
+}
+
+func (suite *SubstitutionTest) TestEncoding() {
+	for _, cs := range suite.cases {
+		enc, err := NewSubstitution(cs.key).Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *SubstitutionTest) TestDecoding() {
+	for _, cs := range suite.cases {
+		dec, err := NewSubstitution(cs.key).Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.plain, dec)
+	}
+}
+
+func (suite *SubstitutionTest) TestSolve() {
+	plain := kerckhoffs
+	enc, err := NewSubstitution("QWERTYUIOPASDFGHJKLZXCVBNM").Encode(plain)
+	suite.Nil(err)
+
+	reports := 0
+	solution, err := SolveSubstitution(enc, SolverSettings{
+		Seed: 1,
+		Progress: func(progress SolverProgress) {
+			reports++
+			suite.Len(progress.Key, 26)
+		},
+	})
+	suite.Nil(err)
+	suite.Equal(plain, solution.Plaintext)
+	suite.Greater(reports, 0)
+
+	// the key decodes the ciphertext as found
+	dec, err := NewSubstitution(solution.Key).Decode(enc)
+	suite.Nil(err)
+	suite.Equal(solution.Plaintext, dec)
+
+	// the same seed finds the same key
+	again, err := SolveSubstitution(enc, SolverSettings{Seed: 1})
+	suite.Nil(err)
+	suite.Equal(solution.Key, again.Key)
+}
+
+func (suite *SubstitutionTest) TestErrors() {
+	_, err := SolveSubstitution("abc", SolverSettings{})
+	suite.Equal("not enough letters to solve", err.Error())
+}
+
+func TestSubstitution(t *testing.T) {
+	suite.Run(t, new(SubstitutionTest))
+}
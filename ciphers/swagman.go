@@ -0,0 +1,132 @@
+package ciphers
+
+import (
+	"errors"
+	"fmt"
+	lookup "github.com/ubermensch/ciphers/lookup"
+	"slices"
+	"strings"
+)
+
+// https://www.cryptogram.org/resource-area/cipher-types/
+//
+// The key is a Latin square of the digits 1 to n. The message is
+// written in rows, n rows deep, under the key square repeated across
+// it. Each letter moves down its column to the row given by the key
+// digit over it, and the result is read off by columns.
+type Swagman struct {
+	square [][]int
+	// letters kept from the message
+	alphabet *lookup.Alphabet
+	Encoder
+	Decoder
+}
+
+// Reads a key square written as rows of digits separated by spaces,
+// commas or slashes, e.g. `231 312 123`.
+func ParseSwagmanKey(key string) ([][]int, error) {
+	rows := strings.FieldsFunc(key, func(c rune) bool {
+		return c == ' ' || c == ',' || c == '/' || c == '\n' || c == '\t'
+	})
+
+	square := make([][]int, len(rows))
+	for i, row := range rows {
+		for _, c := range row {
+			if c < '1' || c > '9' {
+				return nil, fmt.Errorf("expected digits 1 to 9 in key square: %s", string(c))
+			}
+			square[i] = append(square[i], int(c-'0'))
+		}
+	}
+	return square, nil
+}
+
+// Every row and column must hold each of 1 to n once.
+func (s *Swagman) validate() error {
+	size := len(s.square)
+	if size < 2 {
+		return errors.New("key square must be at least 2 x 2")
+	}
+
+	for _, row := range s.square {
+		if len(row) != size {
+			return errors.New("key square must be square")
+		}
+	}
+
+	for r, row := range s.square {
+		column := make([]int, size)
+		for c := range row {
+			column[c] = s.square[c][r]
+		}
+		for digit := 1; digit <= size; digit++ {
+			if !slices.Contains(row, digit) {
+				return fmt.Errorf("row %d of key square is missing %d", r+1, digit)
+			}
+			if !slices.Contains(column, digit) {
+				return fmt.Errorf("column %d of key square is missing %d", r+1, digit)
+			}
+		}
+	}
+	return nil
+}
+
+// Maps each message position to its position in the ciphertext, for a
+// message of `length` letters.
+func (s *Swagman) positions(length int) []int {
+	size := len(s.square)
+	width := length / size
+	positions := make([]int, length)
+
+	for row := 0; row < size; row++ {
+		for col := 0; col < width; col++ {
+			to := s.square[row][col%size] - 1
+			positions[row*width+col] = col*size + to
+		}
+	}
+	return positions
+}
+
+// Encode pads the message with `X`, or the last letter of an alphabet
+// without one, to fill the last column.
+func (s *Swagman) Encode(str string) (string, error) {
+	if err := s.validate(); err != nil {
+		return "", err
+	}
+
+	plain := []rune(s.alphabet.Filter(str))
+	for len(plain)%len(s.square) != 0 || len(plain) == 0 {
+		plain = append(plain, paddingLetter(s.alphabet))
+	}
+
+	encoded := make([]rune, len(plain))
+	for from, to := range s.positions(len(plain)) {
+		encoded[to] = plain[from]
+	}
+	return string(encoded), nil
+}
+
+func (s *Swagman) Decode(str string) (string, error) {
+	if err := s.validate(); err != nil {
+		return "", err
+	}
+
+	cipher := []rune(s.alphabet.Filter(str))
+	if len(cipher)%len(s.square) != 0 {
+		return "", fmt.Errorf("ciphertext length must be a multiple of %d", len(s.square))
+	}
+
+	decoded := make([]rune, len(cipher))
+	for from, to := range s.positions(len(cipher)) {
+		decoded[from] = cipher[to]
+	}
+	return string(decoded), nil
+}
+
+// `square` holds the rows of the key, each a permutation of 1 to n.
+func NewSwagman(square [][]int, opts ...Option) *Swagman {
+	return &Swagman{
+		square:   square,
+		alphabet: newOptions(opts).alphabet,
+	}
+}
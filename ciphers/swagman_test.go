@@ -0,0 +1,60 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SwagmanTest struct {
+	suite.Suite
+	swagman *Swagman
+}
+
+func (suite *SwagmanTest) SetupTest() {
+	square, err := ParseSwagmanKey("231 312 123")
+	suite.Nil(err)
+	suite.swagman = NewSwagman(square)
+}
+
+func (suite *SwagmanTest) TestParseKey() {
+	square, err := ParseSwagmanKey("12,21")
+	suite.Nil(err)
+	suite.Equal([][]int{{1, 2}, {2, 1}}, square)
+
+	_, err = ParseSwagmanKey("12 2a")
+	suite.EqualError(err, "expected digits 1 to 9 in key square: a")
+}
+
+func (suite *SwagmanTest) TestEncoding() {
+	enc, err := suite.swagman.Encode("Attack at dawn")
+	suite.Nil(err)
+	suite.Equal("DACKATTAWNAT", enc)
+
+	enc, err = suite.swagman.Encode("Attack at")
+	suite.Nil(err)
+	suite.Equal("AAACTTTKX", enc)
+}
+
+func (suite *SwagmanTest) TestDecoding() {
+	dec, err := suite.swagman.Decode("DACKATTAWNAT")
+	suite.Nil(err)
+	suite.Equal("ATTACKATDAWN", dec)
+}
+
+func (suite *SwagmanTest) TestErrors() {
+	_, err := suite.swagman.Decode("DACKA")
+	suite.EqualError(err, "ciphertext length must be a multiple of 3")
+
+	_, err = NewSwagman([][]int{{1, 2}, {1, 2}}).Encode("text")
+	suite.EqualError(err, "column 1 of key square is missing 2")
+
+	_, err = NewSwagman([][]int{{1, 2}, {2}}).Encode("text")
+	suite.EqualError(err, "key square must be square")
+
+	_, err = NewSwagman([][]int{{1}}).Encode("text")
+	suite.EqualError(err, "key square must be at least 2 x 2")
+}
+
+func TestSwagman(t *testing.T) {
+	suite.Run(t, new(SwagmanTest))
+}
@@ -0,0 +1,75 @@
+package ciphers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// https://en.wikipedia.org/wiki/Tap_code
+//
+// Letters are tapped as their row and column in a 5 x 5 Polybius
+// square, with C standing in for K.
+type TapCode struct {
+	Encoder
+	Decoder
+}
+
+const tapSquare = "ABCDEFGHIJLMNOPQRSTUVWXYZ"
+
+// Encode writes each letter as two groups of dots, e.g. `. ...` for C,
+// separating letters with ` / ` and words with ` // `.
+func (t *TapCode) Encode(s string) (string, error) {
+	encodedWords := []string{}
+
+	for _, word := range strings.Fields(s) {
+		letters := []string{}
+		for _, c := range strings.ReplaceAll(prepareInput(word), "K", "C") {
+			i := strings.IndexRune(tapSquare, c)
+			letters = append(letters, strings.Repeat(".", i/5+1)+" "+strings.Repeat(".", i%5+1))
+		}
+		if len(letters) > 0 {
+			encodedWords = append(encodedWords, strings.Join(letters, " / "))
+		}
+	}
+
+	return strings.Join(encodedWords, " // "), nil
+}
+
+// Decode pairs up the groups of dots, so the letter separators are
+// optional. `//` is taken as a word break.
+func (t *TapCode) Decode(s string) (string, error) {
+	var decoded strings.Builder
+	row := 0
+
+	for i, token := range strings.Fields(s) {
+		switch {
+		case token == "/":
+			continue
+		case token == "//":
+			if row != 0 {
+				return "", fmt.Errorf("word break inside letter at %d", i)
+			}
+			decoded.WriteRune(' ')
+			continue
+		case strings.Trim(token, ".") != "" || len(token) > 5:
+			return "", fmt.Errorf("expected one to five dots at %d: %s", i, token)
+		}
+
+		if row == 0 {
+			row = len(token)
+			continue
+		}
+		decoded.WriteByte(tapSquare[(row-1)*5+len(token)-1])
+		row = 0
+	}
+
+	if row != 0 {
+		return "", errors.New("message ends partway through a letter")
+	}
+	return decoded.String(), nil
+}
+
+func NewTapCode() *TapCode {
+	return &TapCode{}
+}
@@ -0,0 +1,47 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type TapCodeTest struct {
+	suite.Suite
+	tap *TapCode
+}
+
+func (suite *TapCodeTest) SetupTest() {
+	suite.tap = NewTapCode()
+}
+
+func (suite *TapCodeTest) TestEncoding() {
+	enc, err := suite.tap.Encode("Kick water!")
+	suite.Nil(err)
+	suite.Equal(". ... / .. .... / . ... / . ... // ..... .. / . . / .... .... / . ..... / .... ..", enc)
+}
+
+func (suite *TapCodeTest) TestDecoding() {
+	dec, err := suite.tap.Decode(". ... / .. .... / . ... / . ... // ..... .. / . . / .... .... / . ..... / .... ..")
+	suite.Nil(err)
+	suite.Equal("CICC WATER", dec)
+
+	// letter separators can be left out
+	dec, err = suite.tap.Decode(".. ...  . .....")
+	suite.Nil(err)
+	suite.Equal("HE", dec)
+}
+
+func (suite *TapCodeTest) TestErrors() {
+	_, err := suite.tap.Decode(". ...... ")
+	suite.EqualError(err, "expected one to five dots at 1: ......")
+
+	_, err = suite.tap.Decode(". .. / .")
+	suite.EqualError(err, "message ends partway through a letter")
+
+	_, err = suite.tap.Decode(". // .")
+	suite.EqualError(err, "word break inside letter at 1")
+}
+
+func TestTapCode(t *testing.T) {
+	suite.Run(t, new(TapCodeTest))
+}
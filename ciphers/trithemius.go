@@ -0,0 +1,52 @@
+package ciphers
+
+import (
+	"errors"
+)
+
+// https://en.wikipedia.org/wiki/Tabula_recta#Trithemius_cipher
+type Trithemius struct {
+	// shift applied to the first character, each following character is
+	// shifted one further along the tabula recta
+	offset int
+	polyalphabetic
+	Encoder
+	Decoder
+}
+
+func (t *Trithemius) Encode(s string) (string, error) {
+	if t.offset < 0 {
+		return "", errors.New("expected non-negative integer offset")
+	}
+
+	encoded, err := t.transform(s, func(c rune, pos int) (rune, error) {
+		return t.shiftChar(c, t.offset+pos)
+	})
+	if err != nil {
+		return "", errors.New("encoding failed")
+	}
+
+	return encoded, nil
+}
+
+func (t *Trithemius) Decode(s string) (string, error) {
+	if t.offset < 0 {
+		return "", errors.New("expected non-negative integer offset")
+	}
+
+	decoded, err := t.transform(s, func(c rune, pos int) (rune, error) {
+		return t.shiftChar(c, -(t.offset + pos))
+	})
+	if err != nil {
+		return "", errors.New("decoding failed")
+	}
+
+	return decoded, nil
+}
+
+func NewTrithemius(offset int, opts ...Option) *Trithemius {
+	return &Trithemius{
+		offset:         offset,
+		polyalphabetic: newPolyalphabetic(newOptions(opts).alphabet),
+	}
+}
@@ -0,0 +1,68 @@
+package ciphers
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type trithemiusCase struct {
+	offset  int
+	plain   string
+	encoded string
+}
+
+type TrithemiusTest struct {
+	suite.Suite
+	cases []*trithemiusCase
+}
+
+func (suite *TrithemiusTest) SetupTest() {
+	suite.cases = []*trithemiusCase{
+		{
+			offset:  0,
+			plain:   "aaaaaa",
+			encoded: "abcdef",
+		},
+		{
+			offset:  0,
+			plain:   "Hello, World",
+			encoded: "Hfnos, Dwavo",
+		},
+		{
+			offset:  3,
+			plain:   "xyz",
+			encoded: "ace",
+		},
+	}
+}
+
+func (suite *TrithemiusTest) TestEncoding() {
+	for _, cs := range suite.cases {
+		tr := NewTrithemius(cs.offset)
+
+		enc, err := tr.Encode(cs.plain)
+		suite.Nil(err)
+		suite.Equal(cs.encoded, enc)
+	}
+}
+
+func (suite *TrithemiusTest) TestDecoding() {
+	for _, cs := range suite.cases {
+		tr := NewTrithemius(cs.offset)
+
+		dec, err := tr.Decode(cs.encoded)
+		suite.Nil(err)
+		suite.Equal(cs.plain, dec)
+	}
+}
+
+func (suite *TrithemiusTest) TestErrors() {
+	tr := NewTrithemius(-1)
+	_, err := tr.Encode("this won't work")
+	suite.NotNil(err)
+	suite.Equal("expected non-negative integer offset", err.Error())
+}
+
+func TestTrithemius(t *testing.T) {
+	suite.Run(t, new(TrithemiusTest))
+}
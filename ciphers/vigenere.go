@@ -2,154 +2,313 @@ package ciphers
 
 import (
 	"errors"
+	analysis "github.com/ubermensch/ciphers/analysis"
 	lookup "github.com/ubermensch/ciphers/lookup"
-	"sync"
+	"math"
+	"strings"
 )
 
+// Longest key SolveVigenere looks for when estimating the period.
+const vigenereMaxPeriod = 20
+
+// Fewest letters in each column for the period estimate to be trusted.
+const vigenereMinColumn = 6
+
+// How many Kasiski distances it takes for the examination to count for
+// half its full weight in the period score.
+const vigenereKasiskiTrust = 4
+
 // https://en.wikipedia.org/wiki/Vigen%C3%A8re_cipher
 type Vigenere struct {
-	key       string
-	lowerRing *lookup.AlphaRing
-	upperRing *lookup.AlphaRing
+	key string
+	polyalphabetic
 	Encoder
 	Decoder
 }
 
-func (v *Vigenere) offset(c rune) int {
-	switch {
-	case c <= 122 && c >= 97:
-		// lower case alpha
-		encInt := int(c) - 97
-		return encInt
-	case c <= 90 && c >= 65:
-		// upper case alpha
-		encInt := int(c) - 65
-		return encInt
-	default:
-		return 0
-	}
+// key repeats until it's the same length as string
+// to encrypt. e.g. input string `attackatdawn` and key
+// `LEMON` gives padded key `LEMONLEMONLE`.
+func (v *Vigenere) keyOffset(pos int) int {
+	key := []rune(v.key)
+	return v.offset(key[pos%len(key)])
 }
 
-func (v *Vigenere) encodeChar(c rune, keyRune rune) (rune, error) {
-	var encoded rune
-	var err error
-
-	offset := v.offset(keyRune)
-	switch {
-	case v.lowerRing.Contains(c):
-		encoded, err = v.lowerRing.Move(c, offset)
-	case v.upperRing.Contains(c):
-		encoded, err = v.upperRing.Move(c, offset)
-	default:
-		encoded, err = c, nil
+func (v *Vigenere) Encode(s string) (string, error) {
+	if len(v.key) == 0 {
+		return "", errors.New("empty key")
 	}
 
+	encoded, err := v.transform(s, func(c rune, pos int) (rune, error) {
+		return v.shiftChar(c, v.keyOffset(pos))
+	})
 	if err != nil {
-		return 0, err
+		return "", errors.New("encoding failed")
 	}
 
 	return encoded, nil
 }
 
-func (v *Vigenere) decodeChar(c rune, keyRune rune) (rune, error) {
-	var decoded rune
-	var err error
-
-	offset := v.offset(keyRune)
-	switch {
-	case v.lowerRing.Contains(c):
-		decoded, err = v.lowerRing.Move(c, -offset)
-	case v.upperRing.Contains(c):
-		decoded, err = v.upperRing.Move(c, -offset)
-	default:
-		decoded, err = c, nil
+func (v *Vigenere) Decode(s string) (string, error) {
+	if len(v.key) == 0 {
+		return "", errors.New("empty key")
 	}
 
+	decoded, err := v.transform(s, func(c rune, pos int) (rune, error) {
+		return v.shiftChar(c, -v.keyOffset(pos))
+	})
 	if err != nil {
-		return 0, err
+		return "", errors.New("decoding failed")
 	}
 
 	return decoded, nil
 }
 
-func (v *Vigenere) Encode(s string) (string, error) {
-	if len(v.key) == 0 {
-		return "", errors.New("empty key")
-	}
+// How well a key length fits the ciphertext.
+type VigenerePeriod struct {
+	Period int
+	// repeated sequences whose distance apart is a multiple of Period
+	KasiskiCount int
+	// mean index of coincidence of the columns, close to English (0.067)
+	// for the right period and its multiples
+	IndexOfCoincidence float64
+	// the three measures combined, the highest being chosen
+	Score float64
+}
 
-	runes := make([]rune, len(s))
-	wg := sync.WaitGroup{}
-	errCount := 0
+// The ciphertext letters enciphered with one letter of the key, and how
+// the key letter was found.
+type VigenereColumn struct {
+	Letters     string
+	Frequencies []analysis.Count
+	// chi-squared against English of the column decoded with each key
+	// letter in turn, the lowest being chosen
+	ChiSquared []float64
+	KeyLetter  rune
+}
 
-	// encode each character in parallel
-	encFunc := func(r rune, pos int, wg *sync.WaitGroup) {
-		defer wg.Done()
-		// key repeats until it's the same length as string
-		// to encrypt. e.g. input string `attackatdawn` and key
-		// `LEMON` gives padded key `LEMONLEMONLE`.
-		keyRune := []rune(v.key)[pos%len(v.key)]
+// Everything SolveVigenere worked out on the way to the key.
+type VigenereSolution struct {
+	Key       string
+	Plaintext string
+	Period    int
+	// period given by the Friedman test on the whole ciphertext, or
+	// +Inf when the letters look random
+	FriedmanEstimate float64
+	// positions and distances count every rune, as the key does
+	Repeats []analysis.Repeat
+	Periods []VigenerePeriod
+	Columns []VigenereColumn
+}
 
-		enc, err := v.encodeChar(r, keyRune)
-		if err != nil {
-			errCount++
+// Key position of each letter of `s`, counting every rune as Encode
+// does, and the letters themselves in the alphabet's case.
+func (v *Vigenere) letterPositions(s string) ([]int, []rune) {
+	positions := []int{}
+	letters := []rune{}
+	for pos, c := range []rune(s) {
+		if folded, ok := v.alphabet.Fold(c); ok {
+			positions = append(positions, pos)
+			letters = append(letters, folded)
 		}
+	}
+	return positions, letters
+}
 
-		runes[pos] = enc
+// Kasiski examination: repeated sequences of three or more letters,
+// moved to message positions.
+func (v *Vigenere) kasiski(positions []int, letters []rune) []analysis.Repeat {
+	repeats := analysis.RepeatedSequences(string(letters), analysis.MinRepeatLength)
+	for i, r := range repeats {
+		for j, p := range r.Positions {
+			r.Positions[j] = positions[p]
+		}
+		for j := range r.Distances {
+			r.Distances[j] = r.Positions[j+1] - r.Positions[j]
+		}
+		repeats[i] = r
 	}
+	return repeats
+}
 
-	for i, curr := range s {
-		wg.Add(1)
-		go encFunc(curr, i, &wg)
+// Splits the letters into `period` columns by key position.
+func vigenereColumns(positions []int, letters []rune, period int) [][]rune {
+	columns := make([][]rune, period)
+	for i, c := range letters {
+		columns[positions[i]%period] = append(columns[positions[i]%period], c)
 	}
+	return columns
+}
 
-	wg.Wait()
-	if errCount > 0 {
-		return "", errors.New("encoding failed")
+// Scores each period from 1 to `maxPeriod` by Kasiski factor counting
+// and the mean index of coincidence of its columns.
+func (v *Vigenere) periods(positions []int, letters []rune, repeats []analysis.Repeat, maxPeriod int) []VigenerePeriod {
+	periods := make([]VigenerePeriod, 0, maxPeriod)
+	for p := 1; p <= maxPeriod; p++ {
+		kasiski := 0
+		for _, r := range repeats {
+			for _, d := range r.Distances {
+				if d%p == 0 {
+					kasiski++
+				}
+			}
+		}
+
+		ioc, counted := 0.0, 0
+		for _, column := range vigenereColumns(positions, letters, p) {
+			if len(column) > 1 {
+				ioc += analysis.IndexOfCoincidence(string(column))
+				counted++
+			}
+		}
+		if counted > 0 {
+			ioc /= float64(counted)
+		}
+
+		periods = append(periods, VigenerePeriod{
+			Period:             p,
+			KasiskiCount:       kasiski,
+			IndexOfCoincidence: ioc,
+		})
 	}
+	return periods
+}
 
-	return string(runes), nil
+// How far the mean column index of coincidence is from random letters
+// to English, from 0 to 1.
+func vigenereIoCFit(p VigenerePeriod, random float64) float64 {
+	fit := (p.IndexOfCoincidence - random) / (lookup.EnglishIndexOfCoincidence - random)
+	return max(0, min(1, fit))
 }
 
-func (v *Vigenere) Decode(s string) (string, error) {
-	if len(v.key) == 0 {
-		return "", errors.New("empty key")
+// Scores each period by how English its columns look, plus the share of
+// Kasiski distances it divides, trusted more the more distances there
+// are, plus up to a tenth for nearness to the Friedman estimate. The
+// columns can't tell the period from its multiples, but a multiple
+// divides fewer of the distances; the period's divisors divide as many
+// distances but have random looking columns.
+func scoreVigenerePeriods(periods []VigenerePeriod, repeats []analysis.Repeat, random float64, friedman float64) {
+	distances := 0
+	for _, r := range repeats {
+		distances += len(r.Distances)
 	}
+	trust := float64(distances) / float64(distances+vigenereKasiskiTrust)
 
-	runes := make([]rune, len(s))
-	wg := sync.WaitGroup{}
-	errCount := 0
+	for i, p := range periods {
+		score := vigenereIoCFit(p, random)
+		if distances > 0 {
+			score += trust * float64(p.KasiskiCount) / float64(distances)
+		}
+		if !math.IsInf(friedman, 1) {
+			score += 0.1 / (1 + math.Abs(math.Log(float64(p.Period)/friedman)))
+		}
+		periods[i].Score = score
+	}
+}
 
-	decFunc := func(r rune, pos int, wg *sync.WaitGroup) {
-		defer wg.Done()
-		// key repeats until it's the same length as string
-		// to encrypt. e.g. input string `attackatdawn` and key
-		// `LEMON` gives padded key `LEMONLEMONLE`.
-		keyRune := []rune(v.key)[pos%len(v.key)]
-		dec, err := v.decodeChar(r, keyRune)
-		if err != nil {
-			errCount++
+// The best scoring period, unless a divisor of it has columns nearly as
+// English, as the columns of a multiple of the period score a little
+// higher by chance with fewer letters in each.
+func bestVigenerePeriod(periods []VigenerePeriod, random float64) int {
+	best := periods[0]
+	for _, p := range periods[1:] {
+		if p.Score > best.Score {
+			best = p
 		}
+	}
 
-		runes[pos] = dec
+	for _, p := range periods {
+		if best.Period%p.Period == 0 &&
+			vigenereIoCFit(p, random) >= 0.8*vigenereIoCFit(best, random) {
+			return p.Period
+		}
 	}
+	return best.Period
+}
 
-	for i, curr := range s {
-		wg.Add(1)
-		go decFunc(curr, i, &wg)
+// Finds the key letter for one column as the shift whose decoding is
+// closest to English letter frequencies.
+func (v *Vigenere) solveColumn(letters []rune) VigenereColumn {
+	alphabet := v.alphabet
+	column := VigenereColumn{
+		Letters:     string(letters),
+		Frequencies: analysis.NGrams(string(letters), 1),
+		ChiSquared:  make([]float64, alphabet.Len()),
 	}
 
-	wg.Wait()
-	if errCount > 0 {
-		return "", errors.New("decoding failed")
+	decoded := make([]rune, len(letters))
+	best := 0
+	for shift := 0; shift < alphabet.Len(); shift++ {
+		for i, c := range letters {
+			decoded[i] = alphabet.At(alphabet.Index(c) - shift)
+		}
+		column.ChiSquared[shift] = analysis.ChiSquared(
+			strings.ToUpper(string(decoded)), lookup.EnglishFrequencies,
+		)
+		if column.ChiSquared[shift] < column.ChiSquared[best] {
+			best = shift
+		}
+	}
+	column.KeyLetter = alphabet.At(best)
+	return column
+}
+
+// Recovers the key of a Vigenère ciphertext and decodes it. Unless
+// `period` is given, each period up to 20 is scored by the index of
+// coincidence of its columns, the Kasiski examination and the Friedman
+// test, and the best is taken. Then each key letter is the shift
+// bringing its column closest to English. Only the letters are used, but
+// the key advances over every rune, as in Decode. Scores only mean
+// anything for English, but any alphabet is accepted.
+func SolveVigenere(s string, period int, opts ...Option) (*VigenereSolution, error) {
+	if period < 0 {
+		return nil, errors.New("period must not be negative")
+	}
+	v := NewVigenere("", opts...)
+	positions, letters := v.letterPositions(s)
+	if len(letters) < 2 {
+		return nil, errors.New("not enough letters to solve")
+	}
+
+	repeats := v.kasiski(positions, letters)
+	maxPeriod := max(1, min(vigenereMaxPeriod, len(letters)/vigenereMinColumn))
+	periods := v.periods(positions, letters, repeats, max(maxPeriod, period))
+	random := 1 / float64(v.alphabet.Len())
+	friedman := math.Inf(1)
+	if ioc := analysis.IndexOfCoincidence(string(letters)); ioc > random {
+		friedman = (lookup.EnglishIndexOfCoincidence - random) / (ioc - random)
+	}
+	scoreVigenerePeriods(periods, repeats, random, friedman)
+	if period == 0 {
+		period = bestVigenerePeriod(periods, random)
+	}
+
+	solution := &VigenereSolution{
+		Period:           period,
+		FriedmanEstimate: friedman,
+		Repeats:          repeats,
+		Periods:          periods,
+	}
+	key := make([]rune, period)
+	for i, column := range vigenereColumns(positions, letters, period) {
+		solution.Columns = append(solution.Columns, v.solveColumn(column))
+		key[i] = solution.Columns[i].KeyLetter
+	}
+	solution.Key = string(key)
+
+	v.key = solution.Key
+	plaintext, err := v.Decode(s)
+	if err != nil {
+		return nil, err
 	}
+	solution.Plaintext = plaintext
 
-	return string(runes), nil
+	return solution, nil
 }
 
-func NewVigenere(key string) *Vigenere {
+func NewVigenere(key string, opts ...Option) *Vigenere {
 	return &Vigenere{
-		key:       key,
-		lowerRing: lookup.NewAlphaRing(true),
-		upperRing: lookup.NewAlphaRing(false),
+		key:            key,
+		polyalphabetic: newPolyalphabetic(newOptions(opts).alphabet),
 	}
 }
@@ -21,9 +21,17 @@ type VigenereTest struct {
 	suite.Suite
 	vigenereEncode *vigenereEncode
 	vigenereDecode *vigenereDecode
+	// long enough to solve
+	plain string
 }
 
 func (suite *VigenereTest) SetupTest() {
+	suite.plain = "It was the best of times, it was the worst of times, it was the age " +
+		"of wisdom, it was the age of foolishness, it was the epoch of belief, it " +
+		"was the epoch of incredulity, it was the season of Light, it was the " +
+		"season of Darkness, it was the spring of hope, it was the winter of " +
+		"despair, we had everything before us, we had nothing before us, we were " +
+		"all going direct to Heaven, we were all going direct the other way."
 	suite.vigenereEncode = &vigenereEncode{
 		keys: []string{
 			"lemon",
@@ -97,6 +105,59 @@ func (suite *VigenereTest) TestErrors() {
 	suite.Equal("empty key", err.Error())
 }
 
+func (suite *VigenereTest) TestSolve() {
+	plain := suite.plain
+
+	for _, key := range []string{"LEMON", "SECRETS", "HISTORICAL"} {
+		enc, err := NewVigenere(key).Encode(plain)
+		suite.Nil(err)
+
+		solution, err := SolveVigenere(enc, 0)
+		suite.Nil(err)
+		suite.Equal(key, solution.Key)
+		suite.Equal(plain, solution.Plaintext)
+		suite.Equal(len(key), solution.Period)
+
+		suite.Len(solution.Columns, len(key))
+		for i, column := range solution.Columns {
+			suite.Equal(rune(key[i]), column.KeyLetter)
+			suite.Len(column.ChiSquared, 26)
+		}
+		suite.Equal(len(key), solution.Periods[len(key)-1].Period)
+		suite.Greater(solution.Periods[len(key)-1].KasiskiCount, 0)
+	}
+
+	// a given period is used as is
+	enc, err := NewVigenere("LEMON").Encode(plain)
+	suite.Nil(err)
+	solution, err := SolveVigenere(enc, 10)
+	suite.Nil(err)
+	suite.Equal("LEMONLEMON", solution.Key)
+
+	_, err = SolveVigenere("a", 0)
+	suite.Equal("not enough letters to solve", err.Error())
+	_, err = SolveVigenere(enc, -1)
+	suite.Equal("period must not be negative", err.Error())
+	// checked before the letters
+	_, err = SolveVigenere("a", -1)
+	suite.Equal("period must not be negative", err.Error())
+}
+
+func (suite *VigenereTest) TestPeriod() {
+	enc, err := NewVigenere("LEMON").Encode(suite.plain)
+	suite.Nil(err)
+
+	// the columns of period 10 look more English than those of 5, but
+	// 10 divides fewer of the Kasiski distances
+	solution, err := SolveVigenere(enc, 0)
+	suite.Nil(err)
+	five, ten := solution.Periods[4], solution.Periods[9]
+	suite.Greater(ten.IndexOfCoincidence, five.IndexOfCoincidence)
+	suite.Greater(five.KasiskiCount, ten.KasiskiCount)
+	suite.Greater(five.Score, ten.Score)
+	suite.Equal(5, solution.Period)
+}
+
 func TestVigenere(t *testing.T) {
 	suite.Run(t, new(VigenereTest))
 }
@@ -1,13 +1,19 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
 	"errors"
 	"fmt"
+	analysis "github.com/ubermensch/ciphers/analysis"
 	ciphers "github.com/ubermensch/ciphers/ciphers"
+	lookup "github.com/ubermensch/ciphers/lookup"
 	"github.com/urfave/cli/v2"
 	"log"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 )
 
 func inputString(ctx *cli.Context) (string, error) {
@@ -45,16 +51,150 @@ func handleOutput(ctx *cli.Context, output string) error {
 	return nil
 }
 
+// Returns the n-th key argument, counting from the first key or offset
+// argument, e.g. keyArg(cCtx, 1) is the second key.
+func keyArg(ctx *cli.Context, n int) string {
+	return ctx.Args().Get(keyOrOffsetIndex(ctx) + n)
+}
+
+// Parses an optional integer argument, falling back to `def` when absent.
+func intArg(ctx *cli.Context, n int, def int, errMsg string) (int, error) {
+	arg := keyArg(ctx, n)
+	if len(arg) == 0 {
+		return def, nil
+	}
+	i, convErr := strconv.Atoi(arg)
+	if convErr != nil {
+		return 0, errors.New(errMsg)
+	}
+	return i, nil
+}
+
+// Flags choosing the alphabet for ciphers that take one.
+func alphabetFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "alphabet",
+			Value: "latin",
+			Usage: "latin, latin-digits, greek, cyrillic, hebrew, or the letters of any alphabet in order",
+		},
+		&cli.StringFlag{Name: "alphabet-key", Usage: "keyword to mix the alphabet with"},
+	}
+}
+
+// The alphabet chosen with alphabetFlags.
+func alphabetFlag(ctx *cli.Context) (*lookup.Alphabet, error) {
+	name := ctx.String("alphabet")
+	alphabet, ok := lookup.Alphabets[strings.ToLower(name)]
+	if !ok {
+		var err error
+		alphabet, err = lookup.NewAlphabet(name)
+		if err != nil {
+			return nil, errors.New("invalid alphabet: " + err.Error())
+		}
+	}
+	if key := ctx.String("alphabet-key"); len(key) > 0 {
+		alphabet = lookup.NewKeyedAlphabet(key, alphabet)
+	}
+	return alphabet, nil
+}
+
+func alphabetOption(ctx *cli.Context) (ciphers.Option, error) {
+	alphabet, err := alphabetFlag(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ciphers.WithAlphabet(alphabet), nil
+}
+
+type codec interface {
+	ciphers.Encoder
+	ciphers.Decoder
+}
+
+// Builds a cipher command with the usual encode/decode subcommand pair.
+// newCodec constructs the cipher from the command's key arguments and
+// any flags, which are accepted by both subcommands.
+func codecCommand(
+	name string,
+	aliases []string,
+	usage string,
+	argsUsage string,
+	newCodec func(cCtx *cli.Context) (codec, error),
+	flags ...cli.Flag,
+) *cli.Command {
+	return &cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   usage,
+		Subcommands: []*cli.Command{
+			{
+				Name:    "encode",
+				Aliases: []string{"e"},
+				Usage:   "with string to encode and " + argsUsage,
+				Flags:   flags,
+				Action: func(cCtx *cli.Context) error {
+					c, err := newCodec(cCtx)
+					if err != nil {
+						return err
+					}
+
+					str, err := inputString(cCtx)
+					if err != nil {
+						return err
+					}
+
+					encoded, err := c.Encode(str)
+					if err != nil {
+						return errors.New("could not encode: " + err.Error())
+					}
+
+					return handleOutput(cCtx, encoded)
+				},
+			},
+			{
+				Name:    "decode",
+				Aliases: []string{"d"},
+				Usage:   "with string to decode and " + argsUsage,
+				Flags:   flags,
+				Action: func(cCtx *cli.Context) error {
+					c, err := newCodec(cCtx)
+					if err != nil {
+						return err
+					}
+
+					str, err := inputString(cCtx)
+					if err != nil {
+						return err
+					}
+
+					decoded, err := c.Decode(str)
+					unknown := &ciphers.UnknownGroupsError{}
+					if errors.As(err, &unknown) {
+						// the rest of the message is still worth having
+						fmt.Fprintln(os.Stderr, "warning: "+err.Error())
+					} else if err != nil {
+						return errors.New("could not decode: " + err.Error())
+					}
+
+					return handleOutput(cCtx, decoded)
+				},
+			},
+		},
+	}
+}
+
 func vigenere() *cli.Command {
 	return &cli.Command{
 		Name:    "vigenere",
 		Aliases: []string{"vg"},
-		Usage:   "encode or decode with Vigenère cipher",
+		Usage:   "encode or decode with Vigenère cipher, or recover the key",
 		Subcommands: []*cli.Command{
 			{
 				Name:    "encode",
 				Aliases: []string{"e"},
 				Usage:   "with string to encode and key string",
+				Flags:   alphabetFlags(),
 				Action: func(cCtx *cli.Context) error {
 					keyIdx := keyOrOffsetIndex(cCtx)
 					key := cCtx.Args().Get(keyIdx)
@@ -63,7 +203,11 @@ func vigenere() *cli.Command {
 					if err != nil {
 						return err
 					}
-					vig := ciphers.NewVigenere(key)
+					alphabet, err := alphabetOption(cCtx)
+					if err != nil {
+						return err
+					}
+					vig := ciphers.NewVigenere(key, alphabet)
 					encoded, err := vig.Encode(str)
 					if err != nil {
 						return errors.New("could not encode: " + err.Error())
@@ -81,6 +225,7 @@ func vigenere() *cli.Command {
 				Name:    "decode",
 				Aliases: []string{"d"},
 				Usage:   "with string to decode and key string",
+				Flags:   alphabetFlags(),
 				Action: func(cCtx *cli.Context) error {
 					keyIdx := keyOrOffsetIndex(cCtx)
 					key := cCtx.Args().Get(keyIdx)
@@ -89,7 +234,11 @@ func vigenere() *cli.Command {
 					if err != nil {
 						return err
 					}
-					vig := ciphers.NewVigenere(key)
+					alphabet, err := alphabetOption(cCtx)
+					if err != nil {
+						return err
+					}
+					vig := ciphers.NewVigenere(key, alphabet)
 					decoded, err := vig.Decode(str)
 					if err != nil {
 						return errors.New("could not decode: " + err.Error())
@@ -103,20 +252,91 @@ func vigenere() *cli.Command {
 					return nil
 				},
 			},
+			{
+				Name:    "crack",
+				Aliases: []string{"c"},
+				Usage:   "with string to recover the key by Kasiski and Friedman tests and decode",
+				Flags: append(
+					alphabetFlags(),
+					&cli.IntFlag{Name: "period", Usage: "key length to use instead of estimating it"},
+					&cli.BoolFlag{
+						Name:  "details",
+						Usage: "also print the period scores, repeated sequences and column statistics",
+					},
+				),
+				Action: func(cCtx *cli.Context) error {
+					alphabet, err := alphabetOption(cCtx)
+					if err != nil {
+						return err
+					}
+
+					str, err := inputString(cCtx)
+					if err != nil {
+						return err
+					}
+
+					solution, err := ciphers.SolveVigenere(str, cCtx.Int("period"), alphabet)
+					if err != nil {
+						return errors.New("could not solve: " + err.Error())
+					}
+
+					if cCtx.Bool("details") {
+						return handleOutput(cCtx, formatVigenereSolution(solution))
+					}
+					return handleOutput(cCtx, fmt.Sprintf("%s\n%s", solution.Key, solution.Plaintext))
+				},
+			},
 		},
 	}
 }
 
+// Lays out everything the solver worked out, for following the attack
+// by hand.
+func formatVigenereSolution(solution *ciphers.VigenereSolution) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "key:\t%s\n", solution.Key)
+	fmt.Fprintf(&b, "period:\t%d\n", solution.Period)
+	fmt.Fprintf(&b, "friedman estimate:\t%.2f\n", solution.FriedmanEstimate)
+
+	b.WriteString("\nperiods (period, kasiski count, mean column index of coincidence, score):\n")
+	for _, p := range solution.Periods {
+		fmt.Fprintf(&b, "   %d\t%d\t%.4f\t%.3f\n", p.Period, p.KasiskiCount, p.IndexOfCoincidence, p.Score)
+	}
+
+	b.WriteString("\nrepeated sequences:\n")
+	for _, r := range solution.Repeats {
+		distances := make([]string, len(r.Distances))
+		for i, d := range r.Distances {
+			distances[i] = strconv.Itoa(d)
+		}
+		fmt.Fprintf(&b, "   %s\tdistances %s\n", r.Sequence, strings.Join(distances, ","))
+	}
+
+	b.WriteString("\ncolumns (key letter, chi-squared, most frequent letters):\n")
+	for _, column := range solution.Columns {
+		chi := slices.Min(column.ChiSquared)
+		common := make([]string, 0, 5)
+		for _, c := range column.Frequencies[:min(5, len(column.Frequencies))] {
+			common = append(common, fmt.Sprintf("%s:%d", c.Gram, c.Count))
+		}
+		fmt.Fprintf(&b, "   %s\t%.2f\t%s\n", string(column.KeyLetter), chi, strings.Join(common, " "))
+	}
+
+	fmt.Fprintf(&b, "\n%s", solution.Plaintext)
+	return b.String()
+}
+
 func caesar() *cli.Command {
 	return &cli.Command{
 		Name:    "caesar",
 		Aliases: []string{"cs"},
-		Usage:   "encode or decode with Caesar cipher",
+		Usage:   "encode or decode with Caesar cipher, or try every offset",
 		Subcommands: []*cli.Command{
 			{
 				Name:    "encode",
 				Aliases: []string{"e"},
 				Usage:   "with string to encode and positive integer offset",
+				Flags:   alphabetFlags(),
 				Action: func(cCtx *cli.Context) error {
 					offsetIdx := keyOrOffsetIndex(cCtx)
 					offset, convErr := strconv.Atoi(cCtx.Args().Get(offsetIdx))
@@ -129,7 +349,11 @@ func caesar() *cli.Command {
 						return err
 					}
 
-					cs := ciphers.NewCaesar(offset)
+					alphabet, err := alphabetOption(cCtx)
+					if err != nil {
+						return err
+					}
+					cs := ciphers.NewCaesar(offset, alphabet)
 					encoded, err := cs.Encode(str)
 					if err != nil {
 						return errors.New("could not encode: " + err.Error())
@@ -147,6 +371,7 @@ func caesar() *cli.Command {
 				Name:    "decode",
 				Aliases: []string{"d"},
 				Usage:   "with string to decode and positive integer offset",
+				Flags:   alphabetFlags(),
 				Action: func(cCtx *cli.Context) error {
 					offsetIdx := keyOrOffsetIndex(cCtx)
 					offset, convErr := strconv.Atoi(cCtx.Args().Get(offsetIdx))
@@ -159,7 +384,11 @@ func caesar() *cli.Command {
 						return err
 					}
 
-					cs := ciphers.NewCaesar(offset)
+					alphabet, err := alphabetOption(cCtx)
+					if err != nil {
+						return err
+					}
+					cs := ciphers.NewCaesar(offset, alphabet)
 					decoded, err := cs.Decode(str)
 					if err != nil {
 						return errors.New("could not decode: " + err.Error())
@@ -173,20 +402,71 @@ func caesar() *cli.Command {
 					return nil
 				},
 			},
+			{
+				Name:    "crack",
+				Aliases: []string{"c"},
+				Usage:   "with string to decode using every offset, most English-like first",
+				Flags: append(
+					alphabetFlags(),
+					&cli.IntFlag{Name: "top", Value: 5, Usage: "number of candidates to show, 0 for all"},
+				),
+				Action: func(cCtx *cli.Context) error {
+					alphabet, err := alphabetOption(cCtx)
+					if err != nil {
+						return err
+					}
+
+					str, err := inputString(cCtx)
+					if err != nil {
+						return err
+					}
+
+					candidates := ciphers.BruteForceCaesar(str, alphabet)
+					if top := cCtx.Int("top"); top > 0 && top < len(candidates) {
+						candidates = candidates[:top]
+					}
+
+					lines := make([]string, len(candidates))
+					for i, cand := range candidates {
+						lines[i] = fmt.Sprintf("%d\t%.3f\t%s", cand.Offset, cand.Score, cand.Plaintext)
+					}
+					return handleOutput(cCtx, strings.Join(lines, "\n"))
+				},
+			},
 		},
 	}
 }
 
+// Flags choosing the letters of a Playfair square.
+func playfairFlags() []cli.Flag {
+	return append(alphabetFlags(), &cli.StringFlag{
+		Name:  "merge",
+		Value: "IJ",
+		Usage: "two letters sharing a cell of the square, the second read as the first",
+	})
+}
+
+// The merged pair chosen with playfairFlags, merging nothing unless
+// given two letters.
+func mergedOption(ctx *cli.Context) ciphers.Option {
+	pair := []rune(ctx.String("merge"))
+	if len(pair) != 2 {
+		return ciphers.WithMergedLetters(0, 0)
+	}
+	return ciphers.WithMergedLetters(pair[0], pair[1])
+}
+
 func playfair() *cli.Command {
 	return &cli.Command{
 		Name:    "playfair",
 		Aliases: []string{"pf"},
-		Usage:   "encode or decode with Playfair cipher",
+		Usage:   "encode or decode with Playfair cipher, or recover the key square",
 		Subcommands: []*cli.Command{
 			{
 				Name:    "encode",
 				Aliases: []string{"e"},
 				Usage:   "with string to encode and key string",
+				Flags:   playfairFlags(),
 				Action: func(cCtx *cli.Context) error {
 					keyIdx := keyOrOffsetIndex(cCtx)
 					key := cCtx.Args().Get(keyIdx)
@@ -195,9 +475,15 @@ func playfair() *cli.Command {
 					if err != nil {
 						return err
 					}
-
-					pf := ciphers.NewPlayfair(key)
+					alphabet, err := alphabetOption(cCtx)
+					if err != nil {
+						return err
+					}
+					pf := ciphers.NewPlayfair(key, alphabet, mergedOption(cCtx))
 					encoded, err := pf.Encode(str)
+					if err != nil {
+						return errors.New("could not encode: " + err.Error())
+					}
 
 					outputErr := handleOutput(cCtx, encoded)
 					if outputErr != nil {
@@ -211,6 +497,7 @@ func playfair() *cli.Command {
 				Name:    "decode",
 				Aliases: []string{"d"},
 				Usage:   "with string to decode and key string",
+				Flags:   playfairFlags(),
 				Action: func(cCtx *cli.Context) error {
 					keyIdx := keyOrOffsetIndex(cCtx)
 					key := cCtx.Args().Get(keyIdx)
@@ -219,9 +506,15 @@ func playfair() *cli.Command {
 					if err != nil {
 						return err
 					}
-
-					pf := ciphers.NewPlayfair(key)
+					alphabet, err := alphabetOption(cCtx)
+					if err != nil {
+						return err
+					}
+					pf := ciphers.NewPlayfair(key, alphabet, mergedOption(cCtx))
 					decoded, err := pf.Decode(str)
+					if err != nil {
+						return errors.New("could not decode: " + err.Error())
+					}
 
 					outputErr := handleOutput(cCtx, decoded)
 					if outputErr != nil {
@@ -231,6 +524,1433 @@ func playfair() *cli.Command {
 					return nil
 				},
 			},
+			{
+				Name:    "crack",
+				Aliases: []string{"c"},
+				Usage:   "with string to recover the key square by simulated annealing, printing the best key so far",
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "restarts", Value: 3, Usage: "searches from fresh random squares"},
+					&cli.IntFlag{
+						Name:  "changes",
+						Value: 5000,
+						Usage: "changes to the square tried at each temperature",
+					},
+					&cli.Int64Flag{Name: "seed", Usage: "seed for the random squares and changes"},
+				},
+				Action: func(cCtx *cli.Context) error {
+					str, err := inputString(cCtx)
+					if err != nil {
+						return err
+					}
+
+					solution, err := ciphers.SolvePlayfair(str, ciphers.SolverSettings{
+						Restarts:              cCtx.Int("restarts"),
+						ChangesPerTemperature: cCtx.Int("changes"),
+						Seed:                  cCtx.Int64("seed"),
+						Progress: func(progress ciphers.SolverProgress) {
+							fmt.Fprintf(
+								os.Stderr, "restart %d\t%.1f\t%s\n",
+								progress.Restart+1, progress.Score, progress.Key,
+							)
+						},
+					})
+					if err != nil {
+						return errors.New("could not solve: " + err.Error())
+					}
+
+					var b strings.Builder
+					for _, row := range solution.Grid {
+						fmt.Fprintf(&b, "%s\n", string(row[:]))
+					}
+					fmt.Fprintf(&b, "%s\n%s", solution.Key, solution.Plaintext)
+					return handleOutput(cCtx, b.String())
+				},
+			},
+		},
+	}
+}
+
+func nihilist() *cli.Command {
+	return codecCommand(
+		"nihilist",
+		[]string{"nh"},
+		"encode or decode with Nihilist substitution cipher",
+		"square key and additive key strings",
+		func(cCtx *cli.Context) (codec, error) {
+			return ciphers.NewNihilistSubstitution(keyArg(cCtx, 0), keyArg(cCtx, 1)), nil
+		},
+	)
+}
+
+func nihilistTransposition() *cli.Command {
+	return codecCommand(
+		"nihilist-transposition",
+		[]string{"nt"},
+		"encode or decode with Nihilist transposition cipher",
+		"key string",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewNihilistTransposition(keyArg(cCtx, 0), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func porta() *cli.Command {
+	return codecCommand(
+		"porta",
+		[]string{"pt"},
+		"encode or decode with Porta cipher",
+		"key string",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewPorta(keyArg(cCtx, 0), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func gronsfeld() *cli.Command {
+	return codecCommand(
+		"gronsfeld",
+		[]string{"gf"},
+		"encode or decode with Gronsfeld cipher",
+		"numeric key string",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewGronsfeld(keyArg(cCtx, 0), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func trithemius() *cli.Command {
+	return codecCommand(
+		"trithemius",
+		[]string{"tr"},
+		"encode or decode with Trithemius cipher",
+		"optional non-negative integer starting offset",
+		func(cCtx *cli.Context) (codec, error) {
+			offset, err := intArg(cCtx, 0, 0, "expected non-negative integer offset")
+			if err != nil {
+				return nil, err
+			}
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewTrithemius(offset, alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func quagmire1() *cli.Command {
+	return codecCommand(
+		"quagmire1",
+		[]string{"q1"},
+		"encode or decode with Quagmire I cipher (keyed plaintext alphabet)",
+		"plaintext alphabet key and indicator key strings",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewQuagmireI(keyArg(cCtx, 0), keyArg(cCtx, 1), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func quagmire2() *cli.Command {
+	return codecCommand(
+		"quagmire2",
+		[]string{"q2"},
+		"encode or decode with Quagmire II cipher (keyed cipher alphabet)",
+		"cipher alphabet key and indicator key strings",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewQuagmireII(keyArg(cCtx, 0), keyArg(cCtx, 1), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func quagmire3() *cli.Command {
+	return codecCommand(
+		"quagmire3",
+		[]string{"q3"},
+		"encode or decode with Quagmire III cipher (both alphabets keyed alike)",
+		"alphabet key and indicator key strings",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewQuagmireIII(keyArg(cCtx, 0), keyArg(cCtx, 1), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func quagmire4() *cli.Command {
+	return codecCommand(
+		"quagmire4",
+		[]string{"q4"},
+		"encode or decode with Quagmire IV cipher (both alphabets keyed separately)",
+		"plaintext alphabet key, cipher alphabet key and indicator key strings",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewQuagmireIV(keyArg(cCtx, 0), keyArg(cCtx, 1), keyArg(cCtx, 2), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func alberti() *cli.Command {
+	return codecCommand(
+		"alberti",
+		[]string{"ab"},
+		"encode or decode with Alberti cipher disk",
+		"inner disk alphabet or keyword, index letter, letters per turn and places per turn",
+		func(cCtx *cli.Context) (codec, error) {
+			index := []rune(keyArg(cCtx, 1))
+			if len(index) != 1 {
+				return nil, errors.New("expected a single index letter")
+			}
+			period, err := intArg(cCtx, 2, 0, "expected non-negative integer period")
+			if err != nil {
+				return nil, err
+			}
+			step, err := intArg(cCtx, 3, 1, "expected integer step")
+			if err != nil {
+				return nil, err
+			}
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewAlberti(keyArg(cCtx, 0), index[0], period, step, cCtx.Bool("in-text"), alphabet), nil
+		},
+		append(
+			alphabetFlags(),
+			&cli.BoolFlag{Name: "in-text", Usage: "announce each turn of the disk with an uppercase letter in the ciphertext"},
+		)...,
+	)
+}
+
+func jefferson() *cli.Command {
+	return codecCommand(
+		"jefferson",
+		[]string{"jw"},
+		"encode or decode with Jefferson wheel cipher",
+		"wheel file, line offset and optional comma separated wheel order",
+		func(cCtx *cli.Context) (codec, error) {
+			wheelFile, err := os.Open(keyArg(cCtx, 0))
+			if err != nil {
+				return nil, errors.New("could not read wheel file: " + err.Error())
+			}
+			defer wheelFile.Close()
+
+			wheels, err := ciphers.ParseWheels(wheelFile)
+			if err != nil {
+				return nil, err
+			}
+			offset, err := intArg(cCtx, 1, 1, "expected line offset between 1 and 25")
+			if err != nil {
+				return nil, err
+			}
+
+			order := []int{}
+			if arg := keyArg(cCtx, 2); len(arg) != 0 {
+				for _, num := range strings.Split(arg, ",") {
+					n, convErr := strconv.Atoi(strings.TrimSpace(num))
+					if convErr != nil {
+						return nil, errors.New("expected comma separated wheel numbers")
+					}
+					order = append(order, n)
+				}
+			}
+
+			return ciphers.NewJeffersonWheel(wheels, order, offset)
+		},
+	)
+}
+
+func baconCodec(cCtx *cli.Context) (*ciphers.Bacon, error) {
+	symbols := []rune(cCtx.String("symbols"))
+	if len(symbols) != 2 || symbols[0] == symbols[1] {
+		return nil, errors.New("expected two different symbols")
+	}
+	return ciphers.NewBacon(cCtx.Bool("distinct"), symbols[0], symbols[1]), nil
+}
+
+func carrierStyle(cCtx *cli.Context) (ciphers.CarrierStyle, error) {
+	switch cCtx.String("style") {
+	case "case":
+		return ciphers.CaseStyle, nil
+	case "marker":
+		return ciphers.MarkerStyle, nil
+	case "symbol-set":
+		return ciphers.SymbolSetStyle, nil
+	default:
+		return 0, errors.New("expected carrier style `case`, `marker` or `symbol-set`")
+	}
+}
+
+func bacon() *cli.Command {
+	baconFlags := []cli.Flag{
+		&cli.BoolFlag{Name: "distinct", Usage: "use 26 distinct groups instead of sharing I/J and U/V"},
+		&cli.StringFlag{Name: "symbols", Value: "AB", Usage: "the two symbols written for A and B"},
+	}
+	styleFlag := &cli.StringFlag{Name: "style", Value: "case", Usage: "carrier styling, `case`, `marker` or `symbol-set`"}
+
+	cmd := codecCommand(
+		"bacon",
+		[]string{"bc"},
+		"encode, decode, hide or extract with Baconian cipher",
+		"no key",
+		func(cCtx *cli.Context) (codec, error) {
+			return baconCodec(cCtx)
+		},
+		baconFlags...,
+	)
+
+	cmd.Subcommands = append(cmd.Subcommands,
+		&cli.Command{
+			Name:    "hide",
+			Aliases: []string{"hd"},
+			Usage:   "with string to hide in the carrier text",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{Name: "carrier", Required: true, Usage: "file holding the carrier text"},
+				styleFlag,
+			}, baconFlags...),
+			Action: func(cCtx *cli.Context) error {
+				bc, err := baconCodec(cCtx)
+				if err != nil {
+					return err
+				}
+				style, err := carrierStyle(cCtx)
+				if err != nil {
+					return err
+				}
+
+				carrier, err := os.ReadFile(cCtx.String("carrier"))
+				if err != nil {
+					return errors.New("could not read carrier file: " + err.Error())
+				}
+
+				str, err := inputString(cCtx)
+				if err != nil {
+					return err
+				}
+
+				hidden, err := bc.Hide(str, string(carrier), style)
+				if err != nil {
+					return errors.New("could not hide: " + err.Error())
+				}
+
+				return handleOutput(cCtx, hidden)
+			},
+		},
+		&cli.Command{
+			Name:    "extract",
+			Aliases: []string{"x"},
+			Usage:   "with carrier text to extract a hidden message from",
+			Flags:   append([]cli.Flag{styleFlag}, baconFlags...),
+			Action: func(cCtx *cli.Context) error {
+				bc, err := baconCodec(cCtx)
+				if err != nil {
+					return err
+				}
+				style, err := carrierStyle(cCtx)
+				if err != nil {
+					return err
+				}
+
+				str, err := inputString(cCtx)
+				if err != nil {
+					return err
+				}
+
+				extracted, err := bc.Extract(str, style)
+				if err != nil {
+					return errors.New("could not extract: " + err.Error())
+				}
+
+				return handleOutput(cCtx, extracted)
+			},
+		},
+	)
+
+	return cmd
+}
+
+func morse() *cli.Command {
+	return codecCommand(
+		"morse",
+		[]string{"mo"},
+		"encode or decode International Morse code",
+		"no key",
+		func(cCtx *cli.Context) (codec, error) {
+			return ciphers.NewMorse(
+				cCtx.String("letter-sep"),
+				cCtx.String("word-sep"),
+				cCtx.Bool("prosigns"),
+			), nil
+		},
+		&cli.StringFlag{Name: "letter-sep", Value: " ", Usage: "separator between letters"},
+		&cli.StringFlag{Name: "word-sep", Value: " / ", Usage: "separator between words"},
+		&cli.BoolFlag{Name: "prosigns", Usage: "decode codes shared with punctuation as prosigns, e.g. <AR>"},
+	)
+}
+
+func fractionatedMorse() *cli.Command {
+	return codecCommand(
+		"fractionated-morse",
+		[]string{"fm"},
+		"encode or decode with Fractionated Morse cipher",
+		"key string",
+		func(cCtx *cli.Context) (codec, error) {
+			return ciphers.NewFractionatedMorse(keyArg(cCtx, 0)), nil
+		},
+	)
+}
+
+func morbit() *cli.Command {
+	return codecCommand(
+		"morbit",
+		[]string{"mb"},
+		"encode or decode with Morbit cipher",
+		"9 letter key string",
+		func(cCtx *cli.Context) (codec, error) {
+			return ciphers.NewMorbit(keyArg(cCtx, 0)), nil
+		},
+	)
+}
+
+// Loads the homophone table named by --table, or generates one with
+// --symbols and --seed.
+func homophoneTable(cCtx *cli.Context) (map[rune][]string, error) {
+	if len(cCtx.String("table")) == 0 {
+		return ciphers.GenerateHomophoneTable(cCtx.Int("symbols"), cCtx.Int64("seed"))
+	}
+
+	tableFile, err := os.Open(cCtx.String("table"))
+	if err != nil {
+		return nil, errors.New("could not read table file: " + err.Error())
+	}
+	defer tableFile.Close()
+
+	return ciphers.ParseHomophoneTable(tableFile)
+}
+
+func homophonic() *cli.Command {
+	tableFlags := []cli.Flag{
+		&cli.StringFlag{Name: "table", Usage: "JSON file mapping each letter to its homophones"},
+		&cli.IntFlag{Name: "symbols", Value: 100, Usage: "number of homophones to generate when no table is given"},
+		&cli.Int64Flag{Name: "seed", Usage: "seed for generating the table and choosing homophones"},
+	}
+
+	cmd := codecCommand(
+		"homophonic",
+		[]string{"hp"},
+		"encode or decode with homophonic substitution cipher",
+		"no key",
+		func(cCtx *cli.Context) (codec, error) {
+			table, err := homophoneTable(cCtx)
+			if err != nil {
+				return nil, err
+			}
+
+			choice := ciphers.RandomChoice
+			if cCtx.Bool("round-robin") {
+				choice = ciphers.RoundRobinChoice
+			}
+			return ciphers.NewHomophonic(table, choice, cCtx.Int64("seed"))
+		},
+		append(tableFlags,
+			&cli.BoolFlag{Name: "round-robin", Usage: "take each letter's homophones in turn instead of at random"},
+		)...,
+	)
+
+	cmd.Subcommands = append(cmd.Subcommands, &cli.Command{
+		Name:    "table",
+		Aliases: []string{"t"},
+		Usage:   "print the homophone table as JSON",
+		Flags:   tableFlags,
+		Action: func(cCtx *cli.Context) error {
+			table, err := homophoneTable(cCtx)
+			if err != nil {
+				return err
+			}
+
+			byLetter := map[string][]string{}
+			for c, symbols := range table {
+				byLetter[string(c)] = symbols
+			}
+			out, err := json.MarshalIndent(byLetter, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			return handleOutput(cCtx, string(out))
+		},
+	})
+
+	return cmd
+}
+
+func nomenclator() *cli.Command {
+	return codecCommand(
+		"nomenclator",
+		[]string{"nc"},
+		"encode or decode with a nomenclator codebook",
+		"no key",
+		func(cCtx *cli.Context) (codec, error) {
+			bookFile, err := os.Open(cCtx.String("codebook"))
+			if err != nil {
+				return nil, errors.New("could not read codebook file: " + err.Error())
+			}
+			defer bookFile.Close()
+
+			var book *ciphers.Codebook
+			if strings.HasSuffix(strings.ToLower(bookFile.Name()), ".json") {
+				book, err = ciphers.ParseCodebookJSON(bookFile)
+			} else {
+				book, err = ciphers.ParseCodebookCSV(bookFile)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			choice := ciphers.RandomChoice
+			if cCtx.Bool("round-robin") {
+				choice = ciphers.RoundRobinChoice
+			}
+			return ciphers.NewNomenclator(book, choice, cCtx.Int64("seed"))
+		},
+		&cli.StringFlag{Name: "codebook", Required: true, Usage: "CSV or JSON codebook file"},
+		&cli.Int64Flag{Name: "seed", Usage: "seed for choosing homophones"},
+		&cli.BoolFlag{Name: "round-robin", Usage: "take each letter's homophones in turn instead of at random"},
+	)
+}
+
+func book() *cli.Command {
+	return codecCommand(
+		"book",
+		[]string{"bk"},
+		"encode or decode with a book cipher",
+		"no key",
+		func(cCtx *cli.Context) (codec, error) {
+			text, err := os.ReadFile(cCtx.String("book"))
+			if err != nil {
+				return nil, errors.New("could not read book file: " + err.Error())
+			}
+			index := ciphers.NewBookIndex(string(text), cCtx.Int("lines-per-page"))
+
+			refs := ciphers.OttendorfRefs
+			if cCtx.Bool("word-index") {
+				refs = ciphers.WordIndexRefs
+			}
+			unit := ciphers.WordUnit
+			if cCtx.Bool("letters") {
+				unit = ciphers.LetterUnit
+			}
+			choice := ciphers.FirstOccurrence
+			if cCtx.Bool("random") {
+				choice = ciphers.RandomOccurrence
+			}
+
+			return ciphers.NewBookCipher(index, refs, unit, choice, cCtx.Int64("seed"))
+		},
+		&cli.StringFlag{Name: "book", Required: true, Usage: "plain text book file"},
+		&cli.IntFlag{Name: "lines-per-page", Usage: "lines per page when the book has no form feeds"},
+		&cli.BoolFlag{Name: "word-index", Usage: "refer to words by number instead of page.line.word"},
+		&cli.BoolFlag{Name: "letters", Usage: "encode each letter as a word starting with it"},
+		&cli.BoolFlag{Name: "random", Usage: "choose among a word's occurrences at random"},
+		&cli.Int64Flag{Name: "seed", Usage: "seed for random choices"},
+	)
+}
+
+func readGrille(path string) (string, error) {
+	drawing, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.New("could not read grille file: " + err.Error())
+	}
+	return string(drawing), nil
+}
+
+func turningGrille() *cli.Command {
+	cmd := codecCommand(
+		"turning-grille",
+		[]string{"tg"},
+		"encode or decode with a turning (Fleissner) grille, or generate one",
+		"no key",
+		func(cCtx *cli.Context) (codec, error) {
+			drawing, err := readGrille(cCtx.String("grille"))
+			if err != nil {
+				return nil, err
+			}
+			holes, size, err := ciphers.ParseGrille(drawing)
+			if err != nil {
+				return nil, err
+			}
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewTurningGrille(size, holes, alphabet), nil
+		},
+		append(
+			alphabetFlags(),
+			&cli.StringFlag{Name: "grille", Required: true, Usage: "grille file drawn with # for card and O for holes"},
+		)...,
+	)
+
+	cmd.Subcommands = append(cmd.Subcommands, &cli.Command{
+		Name:    "generate",
+		Aliases: []string{"g"},
+		Usage:   "print a random grille to cut out",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "size", Value: 6, Usage: "cells across, must be even"},
+			&cli.Int64Flag{Name: "seed", Usage: "seed for choosing the holes"},
+		},
+		Action: func(cCtx *cli.Context) error {
+			holes, err := ciphers.RandomGrille(cCtx.Int("size"), cCtx.Int64("seed"))
+			if err != nil {
+				return err
+			}
+			grille := ciphers.NewTurningGrille(cCtx.Int("size"), holes)
+
+			return handleOutput(cCtx, strings.TrimSuffix(grille.String(), "\n"))
+		},
+	})
+
+	return cmd
+}
+
+func cardanGrille() *cli.Command {
+	return &cli.Command{
+		Name:    "cardan-grille",
+		Aliases: []string{"cg"},
+		Usage:   "hide or extract a message in cover text with a Cardan grille",
+		Subcommands: []*cli.Command{
+			{
+				Name:    "hide",
+				Aliases: []string{"hd"},
+				Usage:   "with string to hide, prints the laid out cover text and the grille",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "cover", Required: true, Usage: "file holding the cover text"},
+					&cli.IntFlag{Name: "width", Value: 40, Usage: "characters per line of the page"},
+				},
+				Action: func(cCtx *cli.Context) error {
+					cover, err := os.ReadFile(cCtx.String("cover"))
+					if err != nil {
+						return errors.New("could not read cover file: " + err.Error())
+					}
+
+					str, err := inputString(cCtx)
+					if err != nil {
+						return err
+					}
+
+					cg := ciphers.NewCardanGrille(cCtx.Int("width"))
+					page, holes, err := cg.Hide(str, string(cover))
+					if err != nil {
+						return errors.New("could not hide: " + err.Error())
+					}
+
+					lines := len(strings.Split(page, "\n"))
+					return handleOutput(cCtx, page+"\n\n"+strings.TrimSuffix(cg.Draw(holes, lines), "\n"))
+				},
+			},
+			{
+				Name:    "extract",
+				Aliases: []string{"x"},
+				Usage:   "with laid out cover text to read through the grille",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "grille", Required: true, Usage: "grille file drawn with # for card and O for holes"},
+				},
+				Action: func(cCtx *cli.Context) error {
+					drawing, err := readGrille(cCtx.String("grille"))
+					if err != nil {
+						return err
+					}
+					holes, err := ciphers.ParseCardanGrille(drawing)
+					if err != nil {
+						return err
+					}
+
+					str, err := inputString(cCtx)
+					if err != nil {
+						return err
+					}
+
+					extracted, err := ciphers.NewCardanGrille(0).Extract(str, holes)
+					if err != nil {
+						return errors.New("could not extract: " + err.Error())
+					}
+
+					return handleOutput(cCtx, extracted)
+				},
+			},
+		},
+	}
+}
+
+func pigpen() *cli.Command {
+	cmd := codecCommand(
+		"pigpen",
+		[]string{"pp"},
+		"encode or decode Pigpen glyphs, or draw them as SVG",
+		"no key",
+		func(cCtx *cli.Context) (codec, error) {
+			return ciphers.NewPigpen(), nil
+		},
+	)
+
+	cmd.Subcommands = append(cmd.Subcommands, &cli.Command{
+		Name:  "svg",
+		Usage: "with string to draw as an SVG image",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "per-line", Value: 12, Usage: "glyphs on each line of the image"},
+		},
+		Action: func(cCtx *cli.Context) error {
+			str, err := inputString(cCtx)
+			if err != nil {
+				return err
+			}
+
+			svg, err := ciphers.NewPigpen().SVG(str, cCtx.Int("per-line"))
+			if err != nil {
+				return errors.New("could not draw: " + err.Error())
+			}
+
+			return handleOutput(cCtx, svg)
+		},
+	})
+
+	return cmd
+}
+
+func tapCode() *cli.Command {
+	return codecCommand(
+		"tap-code",
+		[]string{"tc"},
+		"encode or decode tap code as groups of dots",
+		"no key",
+		func(cCtx *cli.Context) (codec, error) {
+			return ciphers.NewTapCode(), nil
+		},
+	)
+}
+
+func semaphore() *cli.Command {
+	return codecCommand(
+		"semaphore",
+		[]string{"sf"},
+		"encode or decode flag semaphore positions",
+		"no key",
+		func(cCtx *cli.Context) (codec, error) {
+			return ciphers.NewSemaphore(), nil
+		},
+	)
+}
+
+func cadenus() *cli.Command {
+	return codecCommand(
+		"cadenus",
+		[]string{"cd"},
+		"encode or decode with Cadenus cipher",
+		"key string",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewCadenus(keyArg(cCtx, 0), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func ragbaby() *cli.Command {
+	return codecCommand(
+		"ragbaby",
+		[]string{"rb"},
+		"encode or decode with Ragbaby cipher",
+		"key string",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewRagbaby(keyArg(cCtx, 0), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func gromark() *cli.Command {
+	return codecCommand(
+		"gromark",
+		[]string{"gm"},
+		"encode or decode with Gromark cipher",
+		"key string and numeric primer",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewGromark(keyArg(cCtx, 0), keyArg(cCtx, 1), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func periodicGromark() *cli.Command {
+	return codecCommand(
+		"periodic-gromark",
+		[]string{"pg"},
+		"encode or decode with Periodic Gromark cipher",
+		"key string",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewPeriodicGromark(keyArg(cCtx, 0), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func nicodemus() *cli.Command {
+	return codecCommand(
+		"nicodemus",
+		[]string{"nd"},
+		"encode or decode with Nicodemus cipher",
+		"key string",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewNicodemus(keyArg(cCtx, 0), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func swagman() *cli.Command {
+	return codecCommand(
+		"swagman",
+		[]string{"sw"},
+		"encode or decode with Swagman cipher",
+		"key square as rows of digits, e.g. \"231 312 123\"",
+		func(cCtx *cli.Context) (codec, error) {
+			square, err := ciphers.ParseSwagmanKey(keyArg(cCtx, 0))
+			if err != nil {
+				return nil, err
+			}
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewSwagman(square, alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func progressiveKey() *cli.Command {
+	return codecCommand(
+		"progressive-key",
+		[]string{"pk"},
+		"encode or decode with Progressive Key cipher",
+		"key string and optional integer progression (default 1)",
+		func(cCtx *cli.Context) (codec, error) {
+			progression, err := intArg(cCtx, 1, 1, "expected integer progression")
+			if err != nil {
+				return nil, err
+			}
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewProgressiveKey(keyArg(cCtx, 0), progression, alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+}
+
+func scytale() *cli.Command {
+	cmd := codecCommand(
+		"scytale",
+		[]string{"sy"},
+		"encode or decode with a scytale, or try every rod size",
+		"rod circumference in letters",
+		func(cCtx *cli.Context) (codec, error) {
+			circumference, err := intArg(cCtx, 0, 0, "expected positive integer circumference")
+			if err != nil {
+				return nil, err
+			}
+
+			var padding rune
+			if pad := []rune(cCtx.String("pad")); len(pad) > 0 {
+				padding = pad[0]
+			}
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewScytale(circumference, padding, alphabet), nil
+		},
+		append(
+			alphabetFlags(),
+			&cli.StringFlag{Name: "pad", Usage: "letter to fill the last turns with when encoding"},
+		)...,
+	)
+
+	cmd.Subcommands = append(cmd.Subcommands, &cli.Command{
+		Name:    "crack",
+		Aliases: []string{"c"},
+		Usage:   "with string to decode using every circumference, most English-like first",
+		Flags: append(
+			alphabetFlags(),
+			&cli.IntFlag{Name: "top", Value: 5, Usage: "number of candidates to show, 0 for all"},
+		),
+		Action: func(cCtx *cli.Context) error {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return err
+			}
+
+			str, err := inputString(cCtx)
+			if err != nil {
+				return err
+			}
+
+			candidates := ciphers.BruteForceScytale(str, alphabet)
+			if top := cCtx.Int("top"); top > 0 && top < len(candidates) {
+				candidates = candidates[:top]
+			}
+
+			lines := make([]string, len(candidates))
+			for i, cand := range candidates {
+				lines[i] = fmt.Sprintf("%d\t%.3f\t%s", cand.Circumference, cand.Score, cand.Plaintext)
+			}
+			return handleOutput(cCtx, strings.Join(lines, "\n"))
+		},
+	})
+
+	return cmd
+}
+
+func columnar() *cli.Command {
+	cmd := codecCommand(
+		"columnar",
+		[]string{"ct"},
+		"encode or decode with columnar transposition, or recover the column order",
+		"key string",
+		func(cCtx *cli.Context) (codec, error) {
+			var padding rune
+			if pad := []rune(cCtx.String("pad")); len(pad) > 0 {
+				padding = pad[0]
+			}
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewColumnar(keyArg(cCtx, 0), padding, alphabet), nil
+		},
+		append(
+			alphabetFlags(),
+			&cli.StringFlag{Name: "pad", Usage: "letter to fill the last row with when encoding"},
+		)...,
+	)
+
+	cmd.Subcommands = append(cmd.Subcommands, &cli.Command{
+		Name:    "crack",
+		Aliases: []string{"c"},
+		Usage:   "with string to recover the column order, printing the best order so far",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "key-length", Usage: "key length to use instead of trying 2 to 15"},
+			&cli.IntFlag{Name: "restarts", Value: 10, Usage: "hill climbs from fresh random orders for each key length"},
+			&cli.IntFlag{
+				Name:  "iterations",
+				Value: 1000,
+				Usage: "changes tried in a row without improvement before a climb gives up",
+			},
+			&cli.Int64Flag{Name: "seed", Usage: "seed for the random orders"},
+		},
+		Action: func(cCtx *cli.Context) error {
+			str, err := inputString(cCtx)
+			if err != nil {
+				return err
+			}
+
+			solution, err := ciphers.SolveColumnar(str, cCtx.Int("key-length"), ciphers.SolverSettings{
+				Restarts:   cCtx.Int("restarts"),
+				Iterations: cCtx.Int("iterations"),
+				Seed:       cCtx.Int64("seed"),
+				Progress: func(progress ciphers.SolverProgress) {
+					fmt.Fprintf(os.Stderr, "%.1f\t%s\n", progress.Score, progress.Key)
+				},
+			})
+			if err != nil {
+				return errors.New("could not solve: " + err.Error())
+			}
+
+			order := make([]string, len(solution.Order))
+			for i, rank := range solution.Order {
+				order[i] = strconv.Itoa(rank + 1)
+			}
+			var b strings.Builder
+			fmt.Fprintf(&b, "column order:\t%s\n", strings.Join(order, " "))
+			fmt.Fprintf(&b, "key:\t%s\n\n", solution.Key)
+			for _, row := range solution.Grid {
+				fmt.Fprintf(&b, "%s\n", strings.Join(strings.Split(row, ""), " "))
+			}
+			fmt.Fprintf(&b, "\n%s", solution.Plaintext)
+			return handleOutput(cCtx, b.String())
+		},
+	})
+
+	return cmd
+}
+
+func hill() *cli.Command {
+	cmd := codecCommand(
+		"hill",
+		[]string{"hl"},
+		"encode or decode with Hill cipher, or recover the key from known plaintext",
+		"key matrix letters row by row",
+		func(cCtx *cli.Context) (codec, error) {
+			return ciphers.NewHill(keyArg(cCtx, 0)), nil
+		},
+	)
+
+	cmd.Subcommands = append(cmd.Subcommands, &cli.Command{
+		Name:    "crack",
+		Aliases: []string{"c"},
+		Usage:   "with string to recover the key matrix from a crib of known plaintext",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "crib", Required: true, Usage: "plaintext known to be in the message"},
+			&cli.IntFlag{Name: "offset", Usage: "letters into the message the crib starts"},
+			&cli.IntFlag{Name: "size", Value: 2, Usage: "letters in each block, the key matrix's width"},
+		},
+		Action: func(cCtx *cli.Context) error {
+			str, err := inputString(cCtx)
+			if err != nil {
+				return err
+			}
+
+			solution, err := ciphers.SolveHill(str, cCtx.String("crib"), cCtx.Int("offset"), cCtx.Int("size"))
+			if err != nil {
+				return errors.New("could not solve: " + err.Error())
+			}
+
+			var b strings.Builder
+			for _, row := range solution.Matrix {
+				cells := make([]string, len(row))
+				for i, v := range row {
+					cells[i] = strconv.Itoa(v)
+				}
+				fmt.Fprintf(&b, "%s\n", strings.Join(cells, "\t"))
+			}
+			fmt.Fprintf(&b, "%s\n%s", solution.Key, solution.Plaintext)
+			return handleOutput(cCtx, b.String())
+		},
+	})
+
+	return cmd
+}
+
+func substitution() *cli.Command {
+	cmd := codecCommand(
+		"substitution",
+		[]string{"sb"},
+		"encode or decode with simple substitution cipher, or break it",
+		"keyword or whole cipher alphabet",
+		func(cCtx *cli.Context) (codec, error) {
+			alphabet, err := alphabetOption(cCtx)
+			if err != nil {
+				return nil, err
+			}
+			return ciphers.NewSubstitution(keyArg(cCtx, 0), alphabet), nil
+		},
+		alphabetFlags()...,
+	)
+
+	cmd.Subcommands = append(cmd.Subcommands, &cli.Command{
+		Name:    "crack",
+		Aliases: []string{"c"},
+		Usage:   "with string to recover the key by hill climbing, printing the best key so far",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "restarts", Value: 50, Usage: "searches from fresh random keys"},
+			&cli.IntFlag{
+				Name:  "iterations",
+				Value: 2000,
+				Usage: "swaps tried in a row without improvement before a search gives up",
+			},
+			&cli.Int64Flag{Name: "seed", Usage: "seed for the random keys"},
+		},
+		Action: func(cCtx *cli.Context) error {
+			str, err := inputString(cCtx)
+			if err != nil {
+				return err
+			}
+
+			solution, err := ciphers.SolveSubstitution(str, ciphers.SolverSettings{
+				Restarts:   cCtx.Int("restarts"),
+				Iterations: cCtx.Int("iterations"),
+				Seed:       cCtx.Int64("seed"),
+				Progress: func(progress ciphers.SolverProgress) {
+					fmt.Fprintf(
+						os.Stderr, "restart %d\t%.1f\t%s\n",
+						progress.Restart+1, progress.Score, progress.Key,
+					)
+				},
+			})
+			if err != nil {
+				return errors.New("could not solve: " + err.Error())
+			}
+
+			return handleOutput(cCtx, fmt.Sprintf("%s\n%s", solution.Key, solution.Plaintext))
+		},
+	})
+
+	return cmd
+}
+
+const cribDragHelp = `drag [1|2] CRIB       slide CRIB through message 1, or 2, and list what the other reads
+accept N              accept the N-th fragment listed
+place 1|2 OFFSET TEXT accept TEXT as message 1 or 2 from OFFSET on
+show                  print both messages as found so far
+quit                  stop`
+
+// Lists fragments as offset, score and fragment, numbered from 1 when
+// `numbered` so that one can be accepted.
+func formatFragments(fragments []ciphers.CribFragment, top int, numbered bool) string {
+	if top > 0 && top < len(fragments) {
+		fragments = fragments[:top]
+	}
+	lines := make([]string, len(fragments))
+	for i, f := range fragments {
+		lines[i] = fmt.Sprintf("%d\t%.3f\t%s", f.Offset, f.Score, f.Fragment)
+		if numbered {
+			lines[i] = fmt.Sprintf("%d)\t%s", i+1, lines[i])
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatKnown(drag *ciphers.CribDrag) string {
+	return fmt.Sprintf("1:\t%s\n2:\t%s", drag.Known(1), drag.Known(2))
+}
+
+// Splits off the first word of `line`. The rest keeps its spacing after
+// the one space that ends the word, since XOR cribs can hold spaces.
+func cutWord(line string) (string, string) {
+	word, rest, _ := strings.Cut(strings.TrimLeft(line, " \t"), " ")
+	return word, rest
+}
+
+// Reads commands from stdin until `quit` or the end of input, dragging
+// cribs and accepting fragments so that both messages grow a piece at a
+// time.
+func interactiveCribDrag(drag *ciphers.CribDrag, top int) error {
+	var fragments []ciphers.CribFragment
+	var crib string
+	cribMessage := 1
+
+	fmt.Println(cribDragHelp)
+	scanner := bufio.NewScanner(os.Stdin)
+	for fmt.Print("> "); scanner.Scan(); fmt.Print("> ") {
+		command, rest := cutWord(scanner.Text())
+		switch command {
+		case "":
+			continue
+		case "drag", "d":
+			cribMessage = 1
+			if word, after := cutWord(rest); word == "1" || word == "2" {
+				cribMessage, _ = strconv.Atoi(word)
+				rest = after
+			}
+			var err error
+			if fragments, err = drag.Drag(rest, cribMessage); err != nil {
+				fmt.Println(err)
+				continue
+			}
+			crib = rest
+			fmt.Println(formatFragments(fragments, top, true))
+		case "accept", "a":
+			n, err := strconv.Atoi(strings.TrimSpace(rest))
+			if err != nil || n < 1 || n > len(fragments) || (top > 0 && n > top) {
+				fmt.Println("expected the number of a fragment listed")
+				continue
+			}
+			if err := drag.Place(crib, cribMessage, fragments[n-1].Offset); err != nil {
+				fmt.Println(err)
+				continue
+			}
+			fmt.Println(formatKnown(drag))
+		case "place", "p":
+			word, rest := cutWord(rest)
+			message, err := strconv.Atoi(word)
+			if err != nil {
+				fmt.Println("expected message 1 or 2")
+				continue
+			}
+			word, text := cutWord(rest)
+			offset, err := strconv.Atoi(word)
+			if err != nil {
+				fmt.Println("expected an offset")
+				continue
+			}
+			if err := drag.Place(text, message, offset); err != nil {
+				fmt.Println(err)
+				continue
+			}
+			fmt.Println(formatKnown(drag))
+		case "show", "s":
+			fmt.Println(formatKnown(drag))
+		case "quit", "q":
+			return nil
+		default:
+			fmt.Println(cribDragHelp)
+		}
+	}
+	fmt.Println()
+	return scanner.Err()
+}
+
+func cribDrag() *cli.Command {
+	return &cli.Command{
+		Name:      "crib-drag",
+		Aliases:   []string{"dr"},
+		Usage:     "drag a crib through ciphertexts sharing a keystream, or a running key, to read the other message",
+		ArgsUsage: "first ciphertext, and the second unless attacking a running key",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "crib", Usage: "word guessed to be in one of the messages"},
+			&cli.IntFlag{Name: "message", Value: 1, Usage: "message the crib is in, 1 or 2"},
+			&cli.BoolFlag{Name: "xor", Usage: "ciphertexts are hex bytes XORed with the keystream, not letters added mod 26"},
+			&cli.IntFlag{Name: "top", Value: 10, Usage: "number of fragments to show, 0 for all"},
+			&cli.BoolFlag{
+				Name:    "interactive",
+				Aliases: []string{"i"},
+				Usage:   "read cribs and fragments to accept from stdin, building up both messages",
+			},
+		},
+		Action: func(cCtx *cli.Context) error {
+			first, err := inputString(cCtx)
+			if err != nil {
+				return err
+			}
+
+			mode := ciphers.AdditiveKeystream
+			if cCtx.Bool("xor") {
+				mode = ciphers.XORKeystream
+			}
+			drag, err := ciphers.NewCribDrag(first, keyArg(cCtx, 0), mode)
+			if err != nil {
+				return err
+			}
+
+			if cCtx.Bool("interactive") {
+				return interactiveCribDrag(drag, cCtx.Int("top"))
+			}
+			if len(cCtx.String("crib")) == 0 {
+				return errors.New("expected a crib, or --interactive")
+			}
+			fragments, err := drag.Drag(cCtx.String("crib"), cCtx.Int("message"))
+			if err != nil {
+				return err
+			}
+			return handleOutput(cCtx, formatFragments(fragments, cCtx.Int("top"), false))
+		},
+	}
+}
+
+// Writes n-gram counts as columns of gram, count and percentage, at
+// most `top` of them unless `top` is 0.
+func formatCounts(b *strings.Builder, title string, counts []analysis.Count, top int) {
+	if top > 0 && top < len(counts) {
+		counts = counts[:top]
+	}
+	fmt.Fprintf(b, "\n%s:\n", title)
+	for _, c := range counts {
+		fmt.Fprintf(b, "   %s\t%d\t%.2f%%\n", c.Gram, c.Count, c.Percent)
+	}
+}
+
+func formatReport(report *analysis.Report, top int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "length:\t%d\n", report.Length)
+	fmt.Fprintf(&b, "index of coincidence:\t%.4f\n", report.IndexOfCoincidence)
+	fmt.Fprintf(&b, "entropy:\t%.3f bits\n", report.Entropy)
+	if report.ChiSquared != nil {
+		fmt.Fprintf(&b, "chi-squared (English):\t%.2f\n", *report.ChiSquared)
+	}
+
+	formatCounts(&b, "unigrams", report.Unigrams, 0)
+	formatCounts(&b, "bigrams", report.Bigrams, top)
+	formatCounts(&b, "trigrams", report.Trigrams, top)
+
+	repeats := report.Repeats
+	if top > 0 && top < len(repeats) {
+		repeats = repeats[:top]
+	}
+	b.WriteString("\nrepeated sequences:\n")
+	for _, r := range repeats {
+		positions := make([]string, len(r.Positions))
+		for i, p := range r.Positions {
+			positions[i] = strconv.Itoa(p)
+		}
+		distances := make([]string, len(r.Distances))
+		for i, d := range r.Distances {
+			distances[i] = strconv.Itoa(d)
+		}
+		fmt.Fprintf(
+			&b, "   %s\tat %s\tdistances %s\n",
+			r.Sequence, strings.Join(positions, ","), strings.Join(distances, ","),
+		)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func analyze() *cli.Command {
+	return &cli.Command{
+		Name:      "analyze",
+		Aliases:   []string{"an"},
+		Usage:     "print letter frequencies, index of coincidence and other statistics of a text",
+		ArgsUsage: "text to analyze",
+		Flags: append(
+			alphabetFlags(),
+			&cli.BoolFlag{Name: "json", Usage: "print the full statistics as JSON"},
+			&cli.IntFlag{
+				Name:  "top",
+				Value: 10,
+				Usage: "number of bigrams, trigrams and repeats to show, 0 for all",
+			},
+		),
+		Action: func(cCtx *cli.Context) error {
+			alphabet, err := alphabetFlag(cCtx)
+			if err != nil {
+				return err
+			}
+
+			str, err := inputString(cCtx)
+			if err != nil {
+				return err
+			}
+
+			// English frequencies only make sense for alphabets with A to Z
+			reference := lookup.EnglishFrequencies
+			for _, c := range lookup.Latin.String() {
+				if !alphabet.Upper().Contains(c) {
+					reference = nil
+					break
+				}
+			}
+			report := analysis.Analyze(str, alphabet, reference)
+
+			if cCtx.Bool("json") {
+				encoded, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return errors.New("could not encode statistics: " + err.Error())
+				}
+				return handleOutput(cCtx, string(encoded))
+			}
+			return handleOutput(cCtx, formatReport(report, cCtx.Int("top")))
+		},
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func formatIdentification(id *analysis.Identification, top int) string {
+	stats := id.Statistics
+	var b strings.Builder
+	fmt.Fprintf(&b, "length:\t%d\n", stats.Length)
+	fmt.Fprintf(&b, "alphabet:\t%s, %d symbols\n", stats.Alphabet, stats.Symbols)
+	fmt.Fprintf(&b, "IC:\t%.4f\n", stats.IndexOfCoincidence)
+	fmt.Fprintf(&b, "MIC:\t%.4f at period %d\n", stats.MaxPeriodicIoC, stats.MaxIoCPeriod)
+	fmt.Fprintf(&b, "LR:\t%.1f%%, longest repeat %d\n", stats.LongRepeats, stats.LongestRepeat)
+	fmt.Fprintf(&b, "DIC:\t%.4f\n", stats.DigraphicIoC)
+	fmt.Fprintf(&b, "EDI:\t%.4f\n", stats.EvenDigraphicIoC)
+	fmt.Fprintf(&b, "normor:\t%d\n", stats.Normor)
+	fmt.Fprintf(&b, "even length:\t%s\n", yesNo(stats.EvenLength))
+	fmt.Fprintf(&b, "has J:\t%s\n", yesNo(stats.HasJ))
+	fmt.Fprintf(&b, "doubled pairs:\t%s\n", yesNo(stats.DoubledDigraph))
+
+	guesses := id.Guesses
+	if top > 0 && top < len(guesses) {
+		guesses = guesses[:top]
+	}
+	if len(guesses) == 0 {
+		b.WriteString("\nno known cipher type fits")
+		return b.String()
+	}
+	b.WriteString("\nlikely types:\n")
+	for _, g := range guesses {
+		fmt.Fprintf(&b, "   %s\t%.1f%%\t%s\n", g.Type, g.Percent, strings.Join(g.Commands, ", "))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func identify() *cli.Command {
+	return &cli.Command{
+		Name:      "identify",
+		Aliases:   []string{"id"},
+		Usage:     "rank the cipher types likely to have written a ciphertext by its statistics",
+		ArgsUsage: "ciphertext to identify",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "json", Usage: "print the statistics and ranking as JSON"},
+			&cli.IntFlag{Name: "top", Value: 5, Usage: "number of cipher types to show, 0 for all"},
+		},
+		Action: func(cCtx *cli.Context) error {
+			str, err := inputString(cCtx)
+			if err != nil {
+				return err
+			}
+
+			id := analysis.Identify(str)
+			if id.Statistics.Length < 2 {
+				return errors.New("not enough letters or digits to identify")
+			}
+
+			if cCtx.Bool("json") {
+				encoded, err := json.MarshalIndent(id, "", "  ")
+				if err != nil {
+					return errors.New("could not encode statistics: " + err.Error())
+				}
+				return handleOutput(cCtx, string(encoded))
+			}
+			return handleOutput(cCtx, formatIdentification(id, cCtx.Int("top")))
 		},
 	}
 }
@@ -244,6 +1964,43 @@ func main() {
 			caesar(),
 			vigenere(),
 			playfair(),
+			nihilist(),
+			nihilistTransposition(),
+			porta(),
+			gronsfeld(),
+			trithemius(),
+			quagmire1(),
+			quagmire2(),
+			quagmire3(),
+			quagmire4(),
+			alberti(),
+			jefferson(),
+			bacon(),
+			morse(),
+			fractionatedMorse(),
+			morbit(),
+			homophonic(),
+			nomenclator(),
+			book(),
+			turningGrille(),
+			cardanGrille(),
+			pigpen(),
+			tapCode(),
+			semaphore(),
+			cadenus(),
+			ragbaby(),
+			gromark(),
+			periodicGromark(),
+			nicodemus(),
+			swagman(),
+			progressiveKey(),
+			scytale(),
+			substitution(),
+			columnar(),
+			hill(),
+			cribDrag(),
+			analyze(),
+			identify(),
 		},
 		Flags: []cli.Flag{
 			&cli.StringFlag{Name: "input-file", Aliases: []string{"if"}},
@@ -0,0 +1,83 @@
+// Builds n-gram tables for the ngram package from a local corpus, as
+// text for ngram.Read or with --binary for ngram.ReadBinary.
+//
+//	ngrams --n 4 --min 3 --output quadgrams.txt corpus/*.txt
+package main
+
+import (
+	"errors"
+	"fmt"
+	ngram "github.com/ubermensch/ciphers/ngram"
+	"github.com/urfave/cli/v2"
+	"io"
+	"log"
+	"os"
+)
+
+func build(cCtx *cli.Context) error {
+	if cCtx.NArg() == 0 {
+		return errors.New("expected at least one corpus file, or - for standard input")
+	}
+
+	counter, err := ngram.NewCounter(cCtx.Int("n"))
+	if err != nil {
+		return err
+	}
+	for _, path := range cCtx.Args().Slice() {
+		var in io.Reader = os.Stdin
+		if path != "-" {
+			file, err := os.Open(path)
+			if err != nil {
+				return errors.New("could not read corpus: " + err.Error())
+			}
+			defer file.Close()
+			in = file
+		}
+		if _, err := counter.ReadFrom(in); err != nil {
+			return errors.New("could not read corpus: " + err.Error())
+		}
+	}
+	if counter.Total() == 0 {
+		return errors.New("no letters in corpus")
+	}
+
+	var out io.Writer = os.Stdout
+	if output := cCtx.String("output"); output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return errors.New("could not write to output file: " + err.Error())
+		}
+		defer file.Close()
+		out = file
+	}
+	write := counter.Write
+	if cCtx.Bool("binary") {
+		write = counter.WriteBinary
+	}
+	if err := write(out, cCtx.Int("min")); err != nil {
+		return errors.New("could not write table: " + err.Error())
+	}
+
+	fmt.Fprintf(os.Stderr, "%d runs of %d letters, %d distinct\n",
+		counter.Total(), cCtx.Int("n"), len(counter.Counts()))
+	return nil
+}
+
+func main() {
+	app := &cli.App{
+		Name:      "ngrams",
+		Usage:     "count runs of letters in a corpus into a table for the ngram package",
+		ArgsUsage: "corpus files, or - for standard input",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "n", Value: 4, Usage: "letters in each run"},
+			&cli.IntFlag{Name: "min", Value: 3, Usage: "leave out runs seen fewer times than this"},
+			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "write the table to a file"},
+			&cli.BoolFlag{Name: "binary", Usage: "write log probabilities in binary, as the embedded tables are"},
+		},
+		Action: build,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,294 @@
+// Measures the cipher type profiles in analysis.CipherTypes from a local
+// corpus of English prose, printing each type's features ready to paste.
+//
+//	profiles --samples 200 --length 300 corpus/*.txt
+package main
+
+import (
+	"errors"
+	"fmt"
+	analysis "github.com/ubermensch/ciphers/analysis"
+	ciphers "github.com/ubermensch/ciphers/ciphers"
+	ngram "github.com/ubermensch/ciphers/ngram"
+	"github.com/urfave/cli/v2"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Writes a random key for one cipher command.
+type encoderMaker func(rng *rand.Rand) (ciphers.Encoder, error)
+
+// `n` random letters, repeats allowed.
+func randomWord(rng *rand.Rand, n int) string {
+	word := make([]byte, n)
+	for i := range word {
+		word[i] = byte('A' + rng.Intn(26))
+	}
+	return string(word)
+}
+
+// A keyword of 5 to 10 letters.
+func randomKeyword(rng *rand.Rand) string {
+	return randomWord(rng, 5+rng.Intn(6))
+}
+
+// `n` random digits.
+func randomDigits(rng *rand.Rand, n int) string {
+	digits := make([]byte, n)
+	for i := range digits {
+		digits[i] = byte('0' + rng.Intn(10))
+	}
+	return string(digits)
+}
+
+// The letters A to Z shuffled.
+func randomAlphabet(rng *rand.Rand) string {
+	alphabet := make([]byte, 26)
+	for i, j := range rng.Perm(26) {
+		alphabet[i] = byte('A' + j)
+	}
+	return string(alphabet)
+}
+
+// A Latin square of 4 to 8 rows, each row the first turned by one more.
+func randomSquare(rng *rand.Rand) [][]int {
+	n := 4 + rng.Intn(5)
+	first := rng.Perm(n)
+	square := make([][]int, n)
+	for r := range square {
+		square[r] = make([]int, n)
+		for c := range square[r] {
+			square[r][c] = first[(r+c)%n] + 1
+		}
+	}
+	return square
+}
+
+// Random keys for every command a profile lists.
+var makers = map[string]encoderMaker{
+	"columnar": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewColumnar(randomKeyword(rng), 0), nil
+	},
+	"scytale": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewScytale(3+rng.Intn(8), 0), nil
+	},
+	"nihilist-transposition": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewNihilistTransposition(randomKeyword(rng)), nil
+	},
+	"turning-grille": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		size := 4 + 2*rng.Intn(3)
+		holes, err := ciphers.RandomGrille(size, rng.Int63())
+		if err != nil {
+			return nil, err
+		}
+		return ciphers.NewTurningGrille(size, holes), nil
+	},
+	"cadenus": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewCadenus(randomWord(rng, 4+rng.Intn(4))), nil
+	},
+	"swagman": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewSwagman(randomSquare(rng)), nil
+	},
+	"caesar": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewCaesar(1 + rng.Intn(25)), nil
+	},
+	"substitution": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewSubstitution(randomAlphabet(rng)), nil
+	},
+	"vigenere": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewVigenere(randomKeyword(rng)), nil
+	},
+	"porta": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewPorta(randomKeyword(rng)), nil
+	},
+	"gronsfeld": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewGronsfeld(randomDigits(rng, 5+rng.Intn(6))), nil
+	},
+	"quagmire1": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewQuagmireI(randomKeyword(rng), randomKeyword(rng)), nil
+	},
+	"quagmire2": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewQuagmireII(randomKeyword(rng), randomKeyword(rng)), nil
+	},
+	"quagmire3": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewQuagmireIII(randomKeyword(rng), randomKeyword(rng)), nil
+	},
+	"quagmire4": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewQuagmireIV(randomKeyword(rng), randomKeyword(rng), randomKeyword(rng)), nil
+	},
+	"nicodemus": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewNicodemus(randomKeyword(rng)), nil
+	},
+	"trithemius": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewTrithemius(rng.Intn(26)), nil
+	},
+	"progressive-key": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewProgressiveKey(randomKeyword(rng), 1+rng.Intn(25)), nil
+	},
+	"alberti": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		index := rune('a' + rng.Intn(26))
+		return ciphers.NewAlberti(randomAlphabet(rng), index, 5+rng.Intn(16), 1+rng.Intn(25), false), nil
+	},
+	"gromark": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewGromark(randomKeyword(rng), randomDigits(rng, 5)), nil
+	},
+	"periodic-gromark": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewPeriodicGromark(randomKeyword(rng)), nil
+	},
+	"ragbaby": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewRagbaby(randomKeyword(rng)), nil
+	},
+	"playfair": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewPlayfair(randomAlphabet(rng)), nil
+	},
+	"hill": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		// keys that can't be inverted fail on encoding, and are tried again
+		return ciphers.NewHill(randomWord(rng, []int{4, 9}[rng.Intn(2)])), nil
+	},
+	"fractionated-morse": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewFractionatedMorse(randomKeyword(rng)), nil
+	},
+	"bacon": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewBacon(false, 'A', 'B'), nil
+	},
+	"nihilist": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewNihilistSubstitution(randomKeyword(rng), randomKeyword(rng)), nil
+	},
+	"morbit": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		return ciphers.NewMorbit(randomWord(rng, 9)), nil
+	},
+	"homophonic": func(rng *rand.Rand) (ciphers.Encoder, error) {
+		table, err := ciphers.GenerateHomophoneTable(100, rng.Int63())
+		if err != nil {
+			return nil, err
+		}
+		return ciphers.NewHomophonic(table, ciphers.RandomChoice, rng.Int63())
+	},
+}
+
+// Encodes `plain` with a fresh random key for `command`, trying new keys
+// until one works.
+func encodeSample(rng *rand.Rand, command string, plain string) (string, error) {
+	maker, ok := makers[command]
+	if !ok {
+		return "", errors.New("no random keys for command " + command)
+	}
+
+	var err error
+	for try := 0; try < 100; try++ {
+		var encoder ciphers.Encoder
+		encoder, err = maker(rng)
+		if err != nil {
+			continue
+		}
+		var enc string
+		if enc, err = encoder.Encode(plain); err == nil {
+			return enc, nil
+		}
+	}
+	return "", fmt.Errorf("could not encode with %s: %w", command, err)
+}
+
+// Rounds to three significant figures, as the profiles are written.
+func round(x float64) string {
+	return strconv.FormatFloat(x, 'g', 3, 64)
+}
+
+// Measures every profile, sharing the samples of a type out evenly
+// between its commands.
+func measure(cCtx *cli.Context) error {
+	if cCtx.NArg() == 0 {
+		return errors.New("expected at least one corpus file, or - for standard input")
+	}
+
+	var corpus strings.Builder
+	for _, path := range cCtx.Args().Slice() {
+		var in io.Reader = os.Stdin
+		if path != "-" {
+			file, err := os.Open(path)
+			if err != nil {
+				return errors.New("could not read corpus: " + err.Error())
+			}
+			defer file.Close()
+			in = file
+		}
+		text, err := io.ReadAll(in)
+		if err != nil {
+			return errors.New("could not read corpus: " + err.Error())
+		}
+		corpus.WriteString(ngram.Normalize(string(text)))
+	}
+
+	text := corpus.String()
+	samples, length := cCtx.Int("samples"), cCtx.Int("length")
+	if samples < 2 || length < 1 {
+		return errors.New("expected at least 2 samples of at least 1 letter")
+	}
+	if len(text) <= length {
+		return fmt.Errorf("corpus has %d letters, need more than %d", len(text), length)
+	}
+
+	rng := rand.New(rand.NewSource(cCtx.Int64("seed")))
+	for _, t := range analysis.CipherTypes {
+		if len(t.Features) == 0 {
+			continue
+		}
+		values := map[string][]float64{}
+		for i := 0; i < samples; i++ {
+			start := rng.Intn(len(text) - length)
+			enc, err := encodeSample(rng, t.Commands[i%len(t.Commands)], text[start:start+length])
+			if err != nil {
+				return err
+			}
+			for name, v := range analysis.IdentifyStatistics(enc).Features() {
+				values[name] = append(values[name], v)
+			}
+		}
+
+		names := make([]string, 0, len(t.Features))
+		for name := range t.Features {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		fmt.Printf("%s:\n", t.Name)
+		for _, name := range names {
+			mean := 0.0
+			for _, v := range values[name] {
+				mean += v
+			}
+			mean /= float64(len(values[name]))
+			spread := 0.0
+			for _, v := range values[name] {
+				spread += (v - mean) * (v - mean)
+			}
+			spread = math.Sqrt(spread / float64(len(values[name])-1))
+			fmt.Printf("\t%q: {%s, %s},\n", name, round(mean), round(spread))
+		}
+	}
+	return nil
+}
+
+func main() {
+	app := &cli.App{
+		Name:      "profiles",
+		Usage:     "measure the statistics of each cipher type under random keys, for analysis.CipherTypes",
+		ArgsUsage: "corpus files of English prose, or - for standard input",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "samples", Value: 200, Usage: "ciphertexts to measure for each type"},
+			&cli.IntFlag{Name: "length", Value: 300, Usage: "letters of prose in each ciphertext"},
+			&cli.Int64Flag{Name: "seed", Usage: "seed for the stretches of prose and the keys"},
+		},
+		Action: measure,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,242 @@
+package lookup
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// An ordered set of letters for ciphers to index and shift within, e.g.
+// a national alphabet, a keyword-mixed alphabet or the letters around
+// a cipher disk. Positions wrap around, so moving past the last letter
+// carries on from the first. An alphabet whose letters have case is
+// paired with the same letters in the other case.
+type Alphabet struct {
+	letters []rune
+	// position of each letter
+	index map[rune]int
+	lower bool
+	// the same letters in the other case, nil when they have none
+	pair *Alphabet
+}
+
+// Common alphabets, in upper case and paired with their lower case.
+// Greek final sigma and the Hebrew final forms aren't letters of their
+// alphabets, so ciphers pass them through unchanged.
+var (
+	Latin       = mustAlphabet("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	LatinDigits = mustAlphabet("ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+	Greek       = mustAlphabet("ΑΒΓΔΕΖΗΘΙΚΛΜΝΞΟΠΡΣΤΥΦΧΨΩ")
+	Cyrillic    = mustAlphabet("АБВГДЕЁЖЗИЙКЛМНОПРСТУФХЦЧШЩЪЫЬЭЮЯ")
+	Hebrew      = mustAlphabet("אבגדהוזחטיכלמנסעפצקרשת")
+)
+
+// Alphabets by name, for choosing one from the command line.
+var Alphabets = map[string]*Alphabet{
+	"latin":        Latin,
+	"latin-digits": LatinDigits,
+	"greek":        Greek,
+	"cyrillic":     Cyrillic,
+	"hebrew":       Hebrew,
+}
+
+func mustAlphabet(letters string) *Alphabet {
+	alphabet, err := NewAlphabet(letters)
+	if err != nil {
+		panic(err)
+	}
+	return alphabet
+}
+
+func newAlphabet(letters []rune, lower bool) *Alphabet {
+	index := make(map[rune]int, len(letters))
+	for i, c := range letters {
+		index[c] = i
+	}
+
+	return &Alphabet{
+		letters: letters,
+		index:   index,
+		lower:   lower,
+	}
+}
+
+// Builds an alphabet from its letters in order. Every rune must be
+// distinct. If the letters have case, the alphabet is paired with the
+// same letters in the other case; runes without case, such as digits,
+// appear in both.
+func NewAlphabet(letters string) (*Alphabet, error) {
+	runes := []rune(letters)
+	if len(runes) == 0 {
+		return nil, errors.New("empty alphabet")
+	}
+
+	seen := map[rune]bool{}
+	lower := false
+	for _, c := range runes {
+		if seen[c] {
+			return nil, fmt.Errorf("duplicate letter in alphabet: %s", string(c))
+		}
+		seen[c] = true
+		lower = lower || unicode.IsLower(c)
+	}
+
+	alphabet := newAlphabet(runes, lower)
+	alphabet.pairCase()
+	return alphabet, nil
+}
+
+// Pairs the alphabet with its letters in the other case, unless some
+// letter has no other case distinct from the rest, e.g. in `AaB`.
+func (a *Alphabet) pairCase() {
+	other := make([]rune, len(a.letters))
+	seen := map[rune]bool{}
+	cased := false
+
+	for i, c := range a.letters {
+		switch {
+		case unicode.IsUpper(c):
+			other[i] = unicode.ToLower(c)
+		case unicode.IsLower(c):
+			other[i] = unicode.ToUpper(c)
+		default:
+			other[i] = c
+		}
+
+		if other[i] != c {
+			cased = true
+			if a.Contains(other[i]) {
+				return
+			}
+		}
+		if seen[other[i]] {
+			return
+		}
+		seen[other[i]] = true
+	}
+
+	if cased {
+		a.pair = newAlphabet(other, !a.lower)
+		a.pair.pair = a
+	}
+}
+
+// Builds a keyword-mixed alphabet: the distinct letters of `key` in
+// order, followed by the rest of `base`. e.g. key `KRYPTOS` over Latin
+// gives `KRYPTOSABCDEFGHIJLMNQUVWXZ`. Key letters may be in either case,
+// and anything not in `base` is ignored.
+func NewKeyedAlphabet(key string, base *Alphabet) *Alphabet {
+	letters := make([]rune, 0, len(base.letters))
+	used := map[rune]bool{}
+
+	for _, c := range key {
+		if c, ok := base.Fold(c); ok && !used[c] {
+			letters = append(letters, c)
+			used[c] = true
+		}
+	}
+	for _, c := range base.letters {
+		if !used[c] {
+			letters = append(letters, c)
+		}
+	}
+
+	alphabet := newAlphabet(letters, base.lower)
+	alphabet.pairCase()
+	return alphabet
+}
+
+// Number of letters in the alphabet.
+func (a *Alphabet) Len() int {
+	return len(a.letters)
+}
+
+func (a *Alphabet) Contains(c rune) bool {
+	_, ok := a.index[c]
+	return ok
+}
+
+// Returns the position of `c` in the alphabet, or -1 if not present
+func (a *Alphabet) Index(c rune) int {
+	if i, ok := a.index[c]; ok {
+		return i
+	}
+	return -1
+}
+
+// Returns the letter at position `i`, wrapping around the alphabet
+func (a *Alphabet) At(i int) rune {
+	n := len(a.letters)
+	return a.letters[(i%n+n)%n]
+}
+
+// Returns the letter `i` positions ahead or behind the `from` letter
+func (a *Alphabet) Move(from rune, i int) (rune, error) {
+	idx, ok := a.index[from]
+	if !ok {
+		return 0, fmt.Errorf("letter not present in alphabet: %s", string(from))
+	}
+	return a.At(idx + i), nil
+}
+
+func (a *Alphabet) String() string {
+	return string(a.letters)
+}
+
+// The same letters in the other case, or nil if they have none.
+func (a *Alphabet) Pair() *Alphabet {
+	return a.pair
+}
+
+// The upper case one of the pair, or the alphabet itself if uncased.
+func (a *Alphabet) Upper() *Alphabet {
+	if a.pair != nil && a.lower {
+		return a.pair
+	}
+	return a
+}
+
+// The lower case one of the pair, or the alphabet itself if uncased.
+func (a *Alphabet) Lower() *Alphabet {
+	if a.pair != nil && !a.lower {
+		return a.pair
+	}
+	return a
+}
+
+// Whichever of the alphabet and its pair holds `c`, or nil if neither.
+func (a *Alphabet) Cased(c rune) *Alphabet {
+	switch {
+	case a.Contains(c):
+		return a
+	case a.pair != nil && a.pair.Contains(c):
+		return a.pair
+	default:
+		return nil
+	}
+}
+
+// Maps a letter of either case to the letter in the same position of
+// this alphabet, e.g. `q` to `Q` in Latin. Reports false for anything
+// that isn't a letter of the alphabet.
+func (a *Alphabet) Fold(c rune) (rune, bool) {
+	cased := a.Cased(c)
+	if cased == nil {
+		return 0, false
+	}
+	return a.letters[cased.Index(c)], true
+}
+
+// Keeps only the letters of the alphabet in `s`, in either case, and
+// writes them in the alphabet's own case, e.g. `Hello, world!` becomes
+// `HELLOWORLD` in Latin.
+func (a *Alphabet) Filter(s string) string {
+	var letters strings.Builder
+	for _, c := range s {
+		if folded, ok := a.Fold(c); ok {
+			letters.WriteRune(folded)
+		}
+	}
+	return letters.String()
+}
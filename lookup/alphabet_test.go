@@ -0,0 +1,254 @@
+package lookup
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+var (
+	lowerRing = Latin.Lower()
+	upperRing = Latin
+)
+
+type containsTest struct {
+	ring       *Alphabet
+	lookupChar rune
+	contains   bool
+}
+
+type indexTest struct {
+	ring   *Alphabet
+	c      rune
+	output int
+}
+
+type moveTest struct {
+	ring   *Alphabet
+	from   rune
+	offset int
+	output rune
+}
+
+type AlphabetTest struct {
+	suite.Suite
+	containsCases []*containsTest
+	indexCases    []*indexTest
+	moveCases     []*moveTest
+}
+
+func (suite *AlphabetTest) SetupTest() {
+	suite.containsCases = []*containsTest{
+		{
+			ring:       lowerRing,
+			lookupChar: 'a',
+			contains:   true,
+		},
+		{
+			ring:       lowerRing,
+			lookupChar: 'z',
+			contains:   true,
+		},
+		{
+			ring:       lowerRing,
+			lookupChar: 'K',
+			contains:   false,
+		},
+		{
+			ring:       lowerRing,
+			lookupChar: '5',
+			contains:   false,
+		},
+		{
+			ring:       upperRing,
+			lookupChar: 'A',
+			contains:   true,
+		},
+		{
+			ring:       upperRing,
+			lookupChar: 'Z',
+			contains:   true,
+		},
+		{
+			ring:       upperRing,
+			lookupChar: 'j',
+			contains:   false,
+		},
+		{
+			ring:       upperRing,
+			lookupChar: '%',
+			contains:   false,
+		},
+	}
+	suite.indexCases = []*indexTest{
+		{
+			ring:   lowerRing,
+			c:      'a',
+			output: 0,
+		},
+		{
+			ring:   upperRing,
+			c:      'Z',
+			output: 25,
+		},
+		{
+			ring:   upperRing,
+			c:      'z',
+			output: -1,
+		},
+	}
+	suite.moveCases = []*moveTest{
+		{
+			ring:   lowerRing,
+			from:   'a',
+			offset: 3,
+			output: 'd',
+		},
+		{
+			ring:   lowerRing,
+			from:   'z',
+			offset: 2,
+			output: 'b',
+		},
+		{
+			ring:   upperRing,
+			from:   'G',
+			offset: 4,
+			output: 'K',
+		},
+		{
+			ring:   upperRing,
+			from:   'W',
+			offset: 5,
+			output: 'B',
+		},
+	}
+}
+
+func (suite *AlphabetTest) TestContains() {
+	for _, cs := range suite.containsCases {
+		suite.Equal(
+			cs.contains,
+			cs.ring.Contains(cs.lookupChar),
+		)
+	}
+}
+
+func (suite *AlphabetTest) TestIndex() {
+	for _, cs := range suite.indexCases {
+		suite.Equal(
+			cs.output,
+			cs.ring.Index(cs.c),
+		)
+	}
+}
+
+func (suite *AlphabetTest) TestMove() {
+	for _, cs := range suite.moveCases {
+		output, err := cs.ring.Move(cs.from, cs.offset)
+		if err != nil {
+			suite.Error(err)
+		}
+		suite.Equal(
+			cs.output,
+			output,
+		)
+	}
+}
+
+func (suite *AlphabetTest) TestAt() {
+	suite.Equal('a', lowerRing.At(0))
+	suite.Equal('Z', upperRing.At(25))
+	suite.Equal('B', upperRing.At(27))
+	suite.Equal('y', lowerRing.At(-2))
+}
+
+func (suite *AlphabetTest) TestKeyed() {
+	keyed := NewKeyedAlphabet("Kryptos", Latin)
+	suite.Equal([]rune("KRYPTOSABCDEFGHIJLMNQUVWXZ"), keyed.letters)
+
+	// duplicate letters, non-letters and case are ignored
+	keyed = NewKeyedAlphabet("Spring fever!", Latin.Lower())
+	suite.Equal([]rune("springfevabcdhjklmoqtuwxyz"), keyed.letters)
+
+	moved, err := keyed.Move('z', 1)
+	suite.Nil(err)
+	suite.Equal('s', moved)
+}
+
+func (suite *AlphabetTest) TestMixed() {
+	mixed, err := NewAlphabet("QWERTY")
+	suite.Nil(err)
+	suite.True(mixed.Contains('E'))
+	suite.False(mixed.Contains('A'))
+
+	moved, err := mixed.Move('T', 3)
+	suite.Nil(err)
+	suite.Equal('W', moved)
+
+	_, err = NewAlphabet("QWERTQ")
+	suite.NotNil(err)
+	suite.Equal("duplicate letter in alphabet: Q", err.Error())
+
+	_, err = NewAlphabet("")
+	suite.NotNil(err)
+	suite.Equal("empty alphabet", err.Error())
+}
+
+func (suite *AlphabetTest) TestNational() {
+	suite.Equal(24, Greek.Len())
+	moved, err := Greek.Move('Ω', 2)
+	suite.Nil(err)
+	suite.Equal('Β', moved)
+	moved, err = Greek.Lower().Move('α', -1)
+	suite.Nil(err)
+	suite.Equal('ω', moved)
+	suite.False(Greek.Lower().Contains('ς'))
+
+	suite.Equal(33, Cyrillic.Len())
+	suite.Equal(6, Cyrillic.Index('Ё'))
+	suite.Equal('я', Cyrillic.Lower().At(-1))
+
+	// Hebrew has no case
+	suite.Nil(Hebrew.Pair())
+	suite.Equal(Hebrew, Hebrew.Lower())
+	suite.Equal('א', Hebrew.At(22))
+
+	// digits are shared by both cases
+	moved, err = LatinDigits.Lower().Move('z', 1)
+	suite.Nil(err)
+	suite.Equal('0', moved)
+	suite.Equal(26, LatinDigits.Lower().Index('0'))
+}
+
+func (suite *AlphabetTest) TestCase() {
+	suite.Equal(Latin, Latin.Lower().Upper())
+	suite.Equal(Latin, Latin.Lower().Pair())
+	suite.Equal(Latin.Lower(), Latin.Cased('q'))
+	suite.Nil(Latin.Cased('é'))
+
+	folded, ok := Greek.Fold('λ')
+	suite.True(ok)
+	suite.Equal('Λ', folded)
+	_, ok = Greek.Fold('L')
+	suite.False(ok)
+
+	// the accented ί isn't a letter of the alphabet
+	keyed := NewKeyedAlphabet("σοφία", Greek)
+	suite.Equal("ΣΟΦΑΒΓΔΕΖΗΘΙΚΛΜΝΞΠΡΤΥΧΨΩ", keyed.String())
+	suite.Equal("σοφαβγδεζηθικλμνξπρτυχψω", keyed.Lower().String())
+
+	// letters whose other case is already in the alphabet aren't paired
+	mixed, err := NewAlphabet("AaB")
+	suite.Nil(err)
+	suite.Nil(mixed.Pair())
+}
+
+func (suite *AlphabetTest) TestFilter() {
+	suite.Equal("HELLOWORLD", Latin.Filter("Hello, world!"))
+	suite.Equal("ΑΒΓ", Greek.Filter("αβγ abc"))
+	suite.Equal("σοφα", NewKeyedAlphabet("", Greek).Lower().Filter("ΣΟΦΊΑ"))
+}
+
+func TestAlphabet(t *testing.T) {
+	suite.Run(t, new(AlphabetTest))
+}
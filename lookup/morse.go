@@ -0,0 +1,33 @@
+package lookup
+
+// International Morse code
+var MorseCode = map[rune]string{
+	'A': ".-", 'B': "-...", 'C': "-.-.", 'D': "-..", 'E': ".",
+	'F': "..-.", 'G': "--.", 'H': "....", 'I': "..", 'J': ".---",
+	'K': "-.-", 'L': ".-..", 'M': "--", 'N': "-.", 'O': "---",
+	'P': ".--.", 'Q': "--.-", 'R': ".-.", 'S': "...", 'T': "-",
+	'U': "..-", 'V': "...-", 'W': ".--", 'X': "-..-", 'Y': "-.--",
+	'Z': "--..",
+
+	'0': "-----", '1': ".----", '2': "..---", '3': "...--", '4': "....-",
+	'5': ".....", '6': "-....", '7': "--...", '8': "---..", '9': "----.",
+
+	'.': ".-.-.-", ',': "--..--", '?': "..--..", '\'': ".----.", '!': "-.-.--",
+	'/': "-..-.", '(': "-.--.", ')': "-.--.-", '&': ".-...", ':': "---...",
+	';': "-.-.-.", '=': "-...-", '+': ".-.-.", '-': "-....-", '_': "..--.-",
+	'"': ".-..-.", '$': "...-..-", '@': ".--.-.",
+}
+
+// Procedural signals, sent as a single run-together character. Several
+// share their code with a punctuation mark, e.g. AR and `+`.
+var MorseProsigns = map[string]string{
+	"AR":  ".-.-.",
+	"AS":  ".-...",
+	"BT":  "-...-",
+	"CT":  "-.-.-",
+	"HH":  "........",
+	"KN":  "-.--.",
+	"SK":  "...-.-",
+	"SN":  "...-.",
+	"SOS": "...---...",
+}
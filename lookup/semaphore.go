@@ -0,0 +1,38 @@
+package lookup
+
+// Flag semaphore positions, as compass directions seen facing the
+// signaller, with `S` for a flag held down at rest. The first seven
+// letters move one flag around the circle while the other rests, and
+// the rest pair up those seven positions.
+// https://en.wikipedia.org/wiki/Flag_semaphore
+var SemaphorePositions = map[rune][2]string{
+	'A': {"S", "SW"}, 'B': {"S", "W"}, 'C': {"S", "NW"}, 'D': {"S", "N"},
+	'E': {"S", "NE"}, 'F': {"S", "E"}, 'G': {"S", "SE"},
+
+	'H': {"SW", "W"}, 'I': {"SW", "NW"}, 'K': {"SW", "N"}, 'L': {"SW", "NE"},
+	'M': {"SW", "E"}, 'N': {"SW", "SE"},
+
+	'O': {"W", "NW"}, 'P': {"W", "N"}, 'Q': {"W", "NE"}, 'R': {"W", "E"},
+	'S': {"W", "SE"},
+
+	'T': {"NW", "N"}, 'U': {"NW", "NE"}, 'Y': {"NW", "E"},
+
+	'J': {"N", "E"}, 'V': {"N", "SE"},
+
+	'W': {"NE", "E"}, 'X': {"NE", "SE"},
+
+	'Z': {"E", "SE"},
+}
+
+// Signals that the following letters A to I stand for 1 to 9, and K
+// for 0. J switches back to letters.
+var SemaphoreNumerals = [2]string{"N", "NE"}
+
+// Letters standing for digits after SemaphoreNumerals.
+var SemaphoreDigits = map[rune]rune{
+	'1': 'A', '2': 'B', '3': 'C', '4': 'D', '5': 'E',
+	'6': 'F', '7': 'G', '8': 'H', '9': 'I', '0': 'K',
+}
+
+// Order of the flag positions, clockwise from rest.
+var SemaphoreDirections = []string{"S", "SW", "W", "NW", "N", "NE", "E", "SE"}
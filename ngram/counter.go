@@ -0,0 +1,140 @@
+package ngram
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Accented Latin letters and ligatures, as the plain letters they're
+// counted as.
+var folds = map[rune]string{
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A", 'Æ': "AE",
+	'Ç': "C", 'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E",
+	'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I", 'Ñ': "N",
+	'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O", 'Ø': "O", 'Œ': "OE",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U", 'Ý': "Y", 'Ÿ': "Y", 'ß': "SS",
+}
+
+// Uppercases `s` and keeps only A to Z, folding accented letters into
+// plain ones, so French, German or Spanish text can be counted and
+// scored like English.
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		c = unicode.ToUpper(c)
+		if c >= 'A' && c <= 'Z' {
+			b.WriteRune(c)
+		} else if fold, ok := folds[c]; ok {
+			b.WriteString(fold)
+		}
+	}
+	return b.String()
+}
+
+// Counts runs of N letters through a corpus, which can be added a piece
+// at a time. Runs carry on from one piece to the next.
+type Counter struct {
+	n      int
+	window []byte
+	counts map[string]int
+	total  int
+}
+
+func NewCounter(n int) (*Counter, error) {
+	if n < MinN || n > MaxN {
+		return nil, fmt.Errorf("n must be between %d and %d", MinN, MaxN)
+	}
+	return &Counter{n: n, counts: map[string]int{}}, nil
+}
+
+// Counts the runs in `text`, after normalizing it.
+func (c *Counter) Add(text string) {
+	letters := Normalize(text)
+	for i := 0; i < len(letters); i++ {
+		c.window = append(c.window, letters[i])
+		if len(c.window) > c.n {
+			c.window = c.window[1:]
+		}
+		if len(c.window) == c.n {
+			c.counts[string(c.window)]++
+			c.total++
+		}
+	}
+}
+
+// Counts the runs in everything read from `r`.
+func (c *Counter) ReadFrom(r io.Reader) (int64, error) {
+	read := int64(0)
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		read += int64(len(line))
+		c.Add(line)
+		if err == io.EOF {
+			return read, nil
+		}
+		if err != nil {
+			return read, err
+		}
+	}
+}
+
+// How many runs have been counted, including repeats.
+func (c *Counter) Total() int {
+	return c.total
+}
+
+func (c *Counter) Counts() map[string]int {
+	return c.counts
+}
+
+// A model of the runs counted so far.
+func (c *Counter) Model() (*Model, error) {
+	return New(c.n, c.counts, c.total)
+}
+
+// Writes the counts as a table Read understands, most common first,
+// leaving out runs seen fewer than `minCount` times. The total still
+// includes them.
+func (c *Counter) Write(w io.Writer, minCount int) error {
+	grams := []string{}
+	for gram, count := range c.counts {
+		if count >= minCount {
+			grams = append(grams, gram)
+		}
+	}
+	slices.SortFunc(grams, func(a, b string) int {
+		if c.counts[a] != c.counts[b] {
+			return c.counts[b] - c.counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "# total %d\n", c.total)
+	for _, gram := range grams {
+		fmt.Fprintf(out, "%s %d\n", gram, c.counts[gram])
+	}
+	return out.Flush()
+}
+
+// Writes a model of the counts as a binary table ReadBinary understands,
+// leaving out runs seen fewer than `minCount` times as Write does.
+func (c *Counter) WriteBinary(w io.Writer, minCount int) error {
+	counts := map[string]int{}
+	for gram, count := range c.counts {
+		if count >= minCount {
+			counts[gram] = count
+		}
+	}
+	m, err := New(c.n, counts, c.total)
+	if err != nil {
+		return err
+	}
+	_, err = m.WriteTo(w)
+	return err
+}
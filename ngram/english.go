@@ -0,0 +1,58 @@
+package ngram
+
+import (
+	"bytes"
+	_ "embed"
+	"sync"
+)
+
+// The English tables were counted with cmd/ngrams from the 437,000
+// letters of Isaac Newton's Opticks (fourth edition, 1730), a public
+// domain text from Project Gutenberg that Go ships as
+// $GOROOT/src/testdata/Isaac.Newton-Opticks.txt. Its English is old and
+// about light, so it leans that way, but it's plain running prose. Runs
+// carry on across word breaks, and every run seen is kept. Each table
+// was written with
+//
+//	go run ./cmd/ngrams --n 4 --min 1 --binary --output ngram/english_quadgrams.bin \
+//		"$(go env GOROOT)/src/testdata/Isaac.Newton-Opticks.txt"
+//
+// with --n 2 and 3 for the others. Tables for other languages can be
+// built the same way from a local corpus and loaded with Read or
+// ReadBinary.
+var (
+	//go:embed english_bigrams.bin
+	englishBigramData []byte
+	//go:embed english_trigrams.bin
+	englishTrigramData []byte
+	//go:embed english_quadgrams.bin
+	englishQuadgramData []byte
+)
+
+func mustRead(data []byte) *Model {
+	m, err := ReadBinary(bytes.NewReader(data))
+	if err != nil {
+		panic("ngram: bad embedded table: " + err.Error())
+	}
+	return m
+}
+
+// Each table is read on first use.
+var (
+	EnglishBigrams   = sync.OnceValue(func() *Model { return mustRead(englishBigramData) })
+	EnglishTrigrams  = sync.OnceValue(func() *Model { return mustRead(englishTrigramData) })
+	EnglishQuadgrams = sync.OnceValue(func() *Model { return mustRead(englishQuadgramData) })
+)
+
+// The English model for runs of `n` letters, or nil if there isn't one.
+func English(n int) *Model {
+	switch n {
+	case 2:
+		return EnglishBigrams()
+	case 3:
+		return EnglishTrigrams()
+	case 4:
+		return EnglishQuadgrams()
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+// Package ngram scores text by how much it looks like a language, using
+// tables of how often each run of two, three or four letters turns up.
+// Solvers call a Model's scorer for every candidate key they try, so it
+// works straight off uppercase A to Z and never allocates.
+package ngram
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Shortest and longest runs of letters a Model can score.
+const (
+	MinN = 1
+	MaxN = 5
+)
+
+// Log probabilities of every run of `n` letters A to Z, indexed as a
+// base 26 number. Each run holds one of 256 levels: the lowest is the
+// floor for runs never seen, and the rest are spread evenly from the
+// least to the most likely run seen. That keeps the quadgram table to a
+// byte a run, and rounds by far less than scores need to tell keys
+// apart.
+type Model struct {
+	n      int
+	levels []uint8
+	// log probability of each level
+	logs  [256]float64
+	floor float64
+}
+
+// Builds a model from counts of runs of `n` letters, out of `total`
+// counted in all. Runs missing from `counts` count as a hundredth of one
+// occurrence.
+func New(n int, counts map[string]int, total int) (*Model, error) {
+	if n < MinN || n > MaxN {
+		return nil, fmt.Errorf("n must be between %d and %d", MinN, MaxN)
+	}
+	if total < 1 {
+		return nil, errors.New("expected positive total")
+	}
+
+	m := newModel(n)
+	m.floor = math.Log(0.01 / float64(total))
+	seen := map[int]float64{}
+	low, high := math.Inf(1), math.Inf(-1)
+	for gram, count := range counts {
+		i, ok := m.index(gram)
+		if !ok {
+			return nil, fmt.Errorf("not %d letters A to Z: %q", n, gram)
+		}
+		if count > 0 {
+			seen[i] = math.Log(float64(count) / float64(total))
+			high = max(high, seen[i])
+			low = min(low, seen[i])
+		}
+	}
+
+	m.logs[0] = m.floor
+	if len(seen) == 0 {
+		return m, nil
+	}
+	step := (high - low) / 254
+	for level := 1; level < len(m.logs); level++ {
+		m.logs[level] = low + float64(level-1)*step
+	}
+	for i, logp := range seen {
+		m.levels[i] = 1
+		if step > 0 {
+			m.levels[i] += uint8(math.Round((logp - low) / step))
+		}
+	}
+	return m, nil
+}
+
+// A model of `n` letters with every run at level 0.
+func newModel(n int) *Model {
+	size := 1
+	for i := 0; i < n; i++ {
+		size *= 26
+	}
+	return &Model{n: n, levels: make([]uint8, size)}
+}
+
+// Reads a table as written by Counter.Write: a `# total N` line, then
+// one `GRAM count` line per run of letters. Blank lines and other lines
+// starting with # are skipped.
+func Read(r io.Reader) (*Model, error) {
+	counts := map[string]int{}
+	total, n := 0, 0
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if comment, ok := strings.CutPrefix(text, "#"); ok {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(comment), "total "); ok {
+				t, err := strconv.Atoi(strings.TrimSpace(value))
+				if err != nil {
+					return nil, fmt.Errorf("line %d: bad total: %s", line, value)
+				}
+				total = t
+			}
+			continue
+		}
+
+		gram, value, ok := strings.Cut(text, " ")
+		count, err := strconv.Atoi(strings.TrimSpace(value))
+		if !ok || err != nil {
+			return nil, fmt.Errorf("line %d: expected a run of letters and a count", line)
+		}
+		if n == 0 {
+			n = len(gram)
+		} else if len(gram) != n {
+			return nil, fmt.Errorf("line %d: expected %d letters: %s", line, n, gram)
+		}
+		counts[gram] = count
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errors.New("no counts in table")
+	}
+
+	// tables without a total are taken to list every run counted
+	if total == 0 {
+		for _, count := range counts {
+			total += count
+		}
+	}
+	return New(n, counts, total)
+}
+
+// Binary tables start with this, then N as one byte, the log
+// probability of each level as 256 little-endian float64s, and last the
+// level of every run, a byte each, in index order.
+const binaryMagic = "NGRAM\x01"
+
+// Reads a table as written by Model.WriteTo, which loads straight into
+// a Model without parsing or counting.
+func ReadBinary(r io.Reader) (*Model, error) {
+	header := make([]byte, len(binaryMagic)+1)
+	if _, err := io.ReadFull(r, header); err != nil || string(header[:len(binaryMagic)]) != binaryMagic {
+		return nil, errors.New("not a binary table")
+	}
+	n := int(header[len(binaryMagic)])
+	if n < MinN || n > MaxN {
+		return nil, fmt.Errorf("n must be between %d and %d", MinN, MaxN)
+	}
+
+	m := newModel(n)
+	if err := binary.Read(r, binary.LittleEndian, &m.logs); err != nil {
+		return nil, errors.New("table ends early")
+	}
+	if _, err := io.ReadFull(r, m.levels); err != nil {
+		return nil, errors.New("table ends early")
+	}
+	m.floor = m.logs[0]
+	return m, nil
+}
+
+// Writes the model as a binary table for ReadBinary.
+func (m *Model) WriteTo(w io.Writer) (int64, error) {
+	out := bufio.NewWriter(w)
+	out.WriteString(binaryMagic)
+	out.WriteByte(byte(m.n))
+	binary.Write(out, binary.LittleEndian, m.logs)
+	out.Write(m.levels)
+	written := int64(len(binaryMagic) + 1 + 8*len(m.logs) + len(m.levels))
+	if err := out.Flush(); err != nil {
+		return 0, err
+	}
+	return written, nil
+}
+
+// The number of letters in each run the model scores.
+func (m *Model) N() int {
+	return m.n
+}
+
+// Log probability given to runs never seen when the table was counted.
+func (m *Model) Floor() float64 {
+	return m.floor
+}
+
+func (m *Model) index(gram string) (int, bool) {
+	if len(gram) != m.n {
+		return 0, false
+	}
+	i := 0
+	for j := 0; j < len(gram); j++ {
+		c := gram[j]
+		if c < 'A' || c > 'Z' {
+			return 0, false
+		}
+		i = i*26 + int(c-'A')
+	}
+	return i, true
+}
+
+// Log probability of one run of N letters, A to Z, or the floor for
+// anything else.
+func (m *Model) LogProbability(gram string) float64 {
+	i, ok := m.index(gram)
+	if !ok {
+		return m.floor
+	}
+	return m.logs[m.levels[i]]
+}
+
+// Sum of the log probabilities of each run of N letters in `text`, which
+// should already be normalized to uppercase A to Z. Any other bytes are
+// skipped, so runs carry on across word breaks just as the tables were
+// counted. Higher is more like the language.
+func (m *Model) Score(text string) float64 {
+	size := len(m.levels)
+	score := 0.0
+	i, seen := 0, 0
+	for j := 0; j < len(text); j++ {
+		c := text[j]
+		if c < 'A' || c > 'Z' {
+			continue
+		}
+		i = (i*26 + int(c-'A')) % size
+		if seen++; seen >= m.n {
+			score += m.logs[m.levels[i]]
+		}
+	}
+	return score
+}
+
+// As Score, for letters given as 0 to 25 for A to Z, which is how
+// solvers hold their decodings.
+func (m *Model) ScoreIndices(letters []int) float64 {
+	score := 0.0
+	switch m.n {
+	// spelled out for the common sizes, which solvers call the most
+	case 4:
+		for i := 0; i+3 < len(letters); i++ {
+			score += m.logs[m.levels[((letters[i]*26+letters[i+1])*26+letters[i+2])*26+letters[i+3]]]
+		}
+	case 3:
+		for i := 0; i+2 < len(letters); i++ {
+			score += m.logs[m.levels[(letters[i]*26+letters[i+1])*26+letters[i+2]]]
+		}
+	case 2:
+		for i := 0; i+1 < len(letters); i++ {
+			score += m.logs[m.levels[letters[i]*26+letters[i+1]]]
+		}
+	default:
+		for i := 0; i+m.n <= len(letters); i++ {
+			k := 0
+			for _, c := range letters[i : i+m.n] {
+				k = k*26 + c
+			}
+			score += m.logs[m.levels[k]]
+		}
+	}
+	return score
+}
@@ -0,0 +1,155 @@
+package ngram
+
+import (
+	"github.com/stretchr/testify/suite"
+	"math"
+	"strings"
+	"testing"
+)
+
+type NgramTest struct {
+	suite.Suite
+	english string
+}
+
+func (suite *NgramTest) SetupTest() {
+	suite.english = Normalize("It was the best of times, it was the worst of times, it was " +
+		"the age of wisdom, it was the age of foolishness")
+}
+
+func (suite *NgramTest) TestNormalize() {
+	suite.Equal("HELLOWORLD", Normalize("Hello, world!"))
+	suite.Equal("ECOLEGRUSSEOEUVRENINO", Normalize("École grüße œuvre niño"))
+}
+
+func (suite *NgramTest) TestNew() {
+	m, err := New(2, map[string]int{"TH": 3, "HE": 1}, 4)
+	suite.Nil(err)
+	suite.Equal(2, m.N())
+	suite.InDelta(math.Log(0.75), m.LogProbability("TH"), 1e-9)
+	suite.InDelta(math.Log(0.01/4), m.LogProbability("QZ"), 1e-9)
+	suite.Equal(m.Floor(), m.LogProbability("th"))
+	suite.InDelta(math.Log(0.75)+math.Log(0.25), m.Score("THE"), 1e-9)
+	suite.InDelta(m.Score("THE"), m.Score("T H-E"), 1e-9)
+	suite.InDelta(m.Score("THE"), m.ScoreIndices([]int{19, 7, 4}), 1e-9)
+
+	_, err = New(6, nil, 1)
+	suite.Equal("n must be between 1 and 5", err.Error())
+	_, err = New(2, map[string]int{"THE": 1}, 1)
+	suite.Equal(`not 2 letters A to Z: "THE"`, err.Error())
+	_, err = New(2, nil, 0)
+	suite.Equal("expected positive total", err.Error())
+}
+
+func (suite *NgramTest) TestCounter() {
+	counter, err := NewCounter(3)
+	suite.Nil(err)
+	counter.Add("The the")
+	// runs carry on from one piece to the next
+	counter.Add("n")
+	suite.Equal(5, counter.Total())
+	suite.Equal(map[string]int{"THE": 2, "HET": 1, "ETH": 1, "HEN": 1}, counter.Counts())
+
+	var b strings.Builder
+	suite.Nil(counter.Write(&b, 2))
+	suite.Equal("# total 5\nTHE 2\n", b.String())
+
+	m, err := Read(strings.NewReader(b.String()))
+	suite.Nil(err)
+	suite.Equal(3, m.N())
+	suite.InDelta(math.Log(0.4), m.LogProbability("THE"), 1e-9)
+	suite.InDelta(math.Log(0.01/5), m.LogProbability("HEN"), 1e-9)
+
+	_, err = NewCounter(0)
+	suite.NotNil(err)
+}
+
+func (suite *NgramTest) TestBinary() {
+	counter, err := NewCounter(2)
+	suite.Nil(err)
+	counter.Add(suite.english)
+	text, err := counter.Model()
+	suite.Nil(err)
+
+	var b strings.Builder
+	suite.Nil(counter.WriteBinary(&b, 1))
+	m, err := ReadBinary(strings.NewReader(b.String()))
+	suite.Nil(err)
+	suite.Equal(2, m.N())
+	suite.Equal(text.Floor(), m.Floor())
+	suite.Equal(text.Score(suite.english), m.Score(suite.english))
+
+	// levels round by at most half a step between the least and most
+	// likely runs seen, which are kept as they are
+	total := float64(counter.Total())
+	low, high := math.Log(1/total), math.Log(6/total)
+	for gram, count := range counter.Counts() {
+		suite.InDelta(math.Log(float64(count)/total), m.LogProbability(gram), (high-low)/254/2+1e-9)
+	}
+	suite.InDelta(high, m.LogProbability("ST"), 1e-9)
+	suite.InDelta(low, m.LogProbability("WO"), 1e-9)
+
+	// runs seen fewer than twice are left at the floor
+	b.Reset()
+	suite.Nil(counter.WriteBinary(&b, 2))
+	m, err = ReadBinary(strings.NewReader(b.String()))
+	suite.Nil(err)
+	suite.Equal(m.Floor(), m.LogProbability("WO"))
+
+	_, err = ReadBinary(strings.NewReader("# total 5\nTHE 2\n"))
+	suite.Equal("not a binary table", err.Error())
+	_, err = ReadBinary(strings.NewReader(b.String()[:100]))
+	suite.Equal("table ends early", err.Error())
+	_, err = ReadBinary(strings.NewReader(binaryMagic + "\x07"))
+	suite.Equal("n must be between 1 and 5", err.Error())
+}
+
+func (suite *NgramTest) TestReadErrors() {
+	_, err := Read(strings.NewReader("# total 10\n"))
+	suite.Equal("no counts in table", err.Error())
+	_, err = Read(strings.NewReader("THE 1\nTH 2\n"))
+	suite.Equal("line 2: expected 3 letters: TH", err.Error())
+	_, err = Read(strings.NewReader("THE\n"))
+	suite.Equal("line 1: expected a run of letters and a count", err.Error())
+}
+
+func (suite *NgramTest) TestEnglish() {
+	for n := 2; n <= 4; n++ {
+		m := English(n)
+		suite.Equal(n, m.N())
+
+		// English beats the same letters reversed
+		reversed := []byte(suite.english)
+		for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+			reversed[i], reversed[j] = reversed[j], reversed[i]
+		}
+		suite.Greater(m.Score(suite.english), m.Score(string(reversed)))
+	}
+	suite.Nil(English(5))
+	suite.Greater(EnglishQuadgrams().LogProbability("TION"), EnglishQuadgrams().LogProbability("QXZJ"))
+}
+
+func (suite *NgramTest) TestNoAllocations() {
+	m := EnglishQuadgrams()
+	letters := make([]int, len(suite.english))
+	for i := range letters {
+		letters[i] = int(suite.english[i] - 'A')
+	}
+	suite.Zero(testing.AllocsPerRun(100, func() {
+		m.Score(suite.english)
+		m.ScoreIndices(letters)
+	}))
+}
+
+func BenchmarkScore(b *testing.B) {
+	m := EnglishQuadgrams()
+	text := Normalize(strings.Repeat("the quick brown fox jumps over the lazy dog ", 20))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Score(text)
+	}
+}
+
+func TestNgram(t *testing.T) {
+	suite.Run(t, new(NgramTest))
+}